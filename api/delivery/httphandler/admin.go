@@ -0,0 +1,63 @@
+package httphandler
+
+import (
+	"employee-management/domain/dto"
+	"employee-management/domain/interfaces"
+	"employee-management/utils/httputil"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type adminHandler struct {
+	employeeUsecase interfaces.EmployeeUsecase
+}
+
+// NewAdminHandler registers the administrative endpoints under api/admin.
+// They are destructive, only registered when enabled in the config and
+// refuse anonymous callers.
+func NewAdminHandler(e gin.IRouter, a interfaces.EmployeeUsecase) {
+	handler := adminHandler{employeeUsecase: a}
+	e.DELETE("api/admin/employee/:employee_id", authenticated(), authorize(dto.PermissionEmployeePurge), handler.PurgeEmployeeHandler)
+}
+
+func (s *adminHandler) PurgeEmployeeHandler(ctx *gin.Context) {
+	var (
+		startTime = time.Now()
+		err       error
+	)
+	defer func() {
+		if err != nil {
+			writeError(ctx, err)
+		}
+	}()
+
+	req := new(dto.DeleteEmployeeRequest)
+	if err = ctx.ShouldBindUri(req); err != nil {
+		err = invalidRequest(err)
+		return
+	}
+
+	err = s.employeeUsecase.PurgeEmployee(ctx.Request.Context(), req.EmployeeID)
+	if err != nil {
+		return
+	}
+
+	data, err := json.Marshal(httputil.StandardEnvelope{
+		Data: "Employee Purged Successfully",
+		Status: &httputil.StandardStatus{
+			Message:   http.StatusText(http.StatusOK),
+			ErrorCode: 0,
+		},
+		Header: &httputil.StandardHeader{
+			TotalData:   1,
+			ProcessTime: time.Since(startTime).Seconds(),
+		},
+	})
+	if err != nil {
+		return
+	}
+	_, _ = httputil.WriteJSONResponse(ctx.Writer, data, http.StatusOK)
+}
@@ -0,0 +1,151 @@
+package httphandler
+
+import (
+	"employee-management/domain/dto"
+	"employee-management/domain/interfaces"
+	"employee-management/utils/httputil"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type apiKeyHandler struct {
+	apiKeyUsecase interfaces.APIKeyUsecase
+}
+
+// NewAPIKeyHandler registers the endpoints managing the API keys of
+// service clients under api/admin.
+func NewAPIKeyHandler(e gin.IRouter, k interfaces.APIKeyUsecase) {
+	handler := apiKeyHandler{apiKeyUsecase: k}
+	e.POST("api/admin/api-keys", authenticated(), authorize(dto.PermissionAPIKeyManage), handler.CreateAPIKeyHandler)
+	e.GET("api/admin/api-keys", authenticated(), authorize(dto.PermissionAPIKeyManage), handler.GetAPIKeysHandler)
+	e.DELETE("api/admin/api-keys/:api_key_id", authenticated(), authorize(dto.PermissionAPIKeyManage), handler.RevokeAPIKeyHandler)
+}
+
+// CreateAPIKeyHandler issues a key. The response is the only place the
+// key is ever shown, so it must not be cached.
+func (s *apiKeyHandler) CreateAPIKeyHandler(ctx *gin.Context) {
+	var (
+		startTime = time.Now()
+		err       error
+	)
+	defer func() {
+		if err != nil {
+			writeError(ctx, err)
+		}
+	}()
+
+	req := new(dto.CreateAPIKeyRequest)
+	if err = ctx.ShouldBindJSON(req); err != nil {
+		err = invalidRequest(err)
+		return
+	}
+
+	key, err := s.apiKeyUsecase.CreateAPIKey(ctx.Request.Context(), req)
+	if err != nil {
+		return
+	}
+	ctx.Header("Cache-Control", "no-store")
+
+	data, err := json.Marshal(httputil.StandardEnvelope{
+		Data: key,
+		Status: &httputil.StandardStatus{
+			Message:   http.StatusText(http.StatusCreated),
+			ErrorCode: 0,
+		},
+		Header: &httputil.StandardHeader{
+			TotalData:   1,
+			ProcessTime: time.Since(startTime).Seconds(),
+		},
+	})
+	if err != nil {
+		return
+	}
+	_, _ = httputil.WriteJSONResponse(ctx.Writer, data, http.StatusCreated)
+}
+
+func (s *apiKeyHandler) GetAPIKeysHandler(ctx *gin.Context) {
+	var (
+		startTime = time.Now()
+		err       error
+	)
+	defer func() {
+		if err != nil {
+			writeError(ctx, err)
+		}
+	}()
+
+	req := new(dto.GetAPIKeys)
+	if err = ctx.ShouldBindQuery(req); err != nil {
+		err = invalidRequest(err)
+		return
+	}
+
+	page, err := s.apiKeyUsecase.ListAPIKeys(ctx.Request.Context(), req)
+	if err != nil {
+		return
+	}
+
+	meta, links := paginationMeta(ctx.Request, page.PageInfo)
+	if len(links) > 0 {
+		ctx.Writer.Header().Set("Link", httputil.LinkHeader(links))
+	}
+
+	data, err := json.Marshal(httputil.StandardEnvelope{
+		Data: page.Keys,
+		Status: &httputil.StandardStatus{
+			Message:   http.StatusText(http.StatusOK),
+			ErrorCode: 0,
+		},
+		Header: &httputil.StandardHeader{
+			TotalData:   int(page.TotalCount),
+			ProcessTime: time.Since(startTime).Seconds(),
+			Meta:        meta,
+		},
+	})
+	if err != nil {
+		return
+	}
+	_, _ = httputil.WriteJSONResponse(ctx.Writer, data, http.StatusOK)
+}
+
+func (s *apiKeyHandler) RevokeAPIKeyHandler(ctx *gin.Context) {
+	var (
+		startTime = time.Now()
+		err       error
+	)
+	defer func() {
+		if err != nil {
+			writeError(ctx, err)
+		}
+	}()
+
+	req := new(dto.GetAPIKeyByIDRequest)
+	if err = ctx.ShouldBindUri(req); err != nil {
+		err = invalidRequest(err)
+		return
+	}
+
+	err = s.apiKeyUsecase.RevokeAPIKey(ctx.Request.Context(), req.APIKeyID)
+	if err != nil {
+		return
+	}
+
+	data, err := json.Marshal(httputil.StandardEnvelope{
+		Data: "API Key Revoked Successfully",
+		Status: &httputil.StandardStatus{
+			Message:   http.StatusText(http.StatusOK),
+			ErrorCode: 0,
+		},
+		Header: &httputil.StandardHeader{
+			TotalData:   1,
+			ProcessTime: time.Since(startTime).Seconds(),
+		},
+	})
+	if err != nil {
+		return
+	}
+	_, _ = httputil.WriteJSONResponse(ctx.Writer, data, http.StatusOK)
+}
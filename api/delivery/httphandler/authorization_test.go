@@ -0,0 +1,97 @@
+package httphandler
+
+import (
+	"context"
+	"employee-management/domain/dto"
+	"employee-management/domain/interfaces"
+	"employee-management/utils/requestctx"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// employeeByIDUsecase serves one employee to GetEmployeeById.
+type employeeByIDUsecase struct {
+	interfaces.EmployeeUsecase
+	employee *dto.Employee
+}
+
+func (u employeeByIDUsecase) GetEmployeeById(ctx context.Context, employeeID int) (*dto.Employee, error) {
+	return u.employee, nil
+}
+
+func serveAs(t *testing.T, principal *dto.Principal, method, target string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	e := gin.New()
+	e.Use(func(c *gin.Context) {
+		if principal != nil {
+			c.Request = c.Request.WithContext(requestctx.WithPrincipal(c.Request.Context(), principal))
+		}
+	})
+	NewEmployeeHandler(e, employeeByIDUsecase{employee: &dto.Employee{ID: 1, Name: "John Doe", Position: "Developer", Salary: 60000}})
+
+	w := httptest.NewRecorder()
+	e.ServeHTTP(w, httptest.NewRequest(method, target, nil))
+	return w
+}
+
+func TestAuthorize(t *testing.T) {
+	viewer := &dto.Principal{Subject: "alice", Permissions: []string{dto.PermissionEmployeeRead}}
+
+	w := serveAs(t, viewer, http.MethodDelete, "/api/employee/1")
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"permission_denied"`)
+	assert.Contains(t, w.Body.String(), "the employee:write permission is required")
+}
+
+func TestSalaryMasking(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal *dto.Principal
+		salary    bool
+	}{
+		{"without authentication", nil, true},
+		{"with salary:read", &dto.Principal{Permissions: []string{dto.PermissionEmployeeRead, dto.PermissionSalaryRead}}, true},
+		{"without salary:read", &dto.Principal{Permissions: []string{dto.PermissionEmployeeRead}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serveAs(t, tt.principal, http.MethodGet, "/api/employee/1")
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.salary, strings.Contains(w.Body.String(), `"salary":60000`))
+		})
+	}
+}
+
+// purgeUsecase records the employees PurgeEmployee was called with.
+type purgeUsecase struct {
+	interfaces.EmployeeUsecase
+	purged *[]int
+}
+
+func (u purgeUsecase) PurgeEmployee(ctx context.Context, employeeID int) error {
+	*u.purged = append(*u.purged, employeeID)
+	return nil
+}
+
+func TestAdminRefusesAnonymousCallers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var purged []int
+	e := gin.New()
+	NewAdminHandler(e, purgeUsecase{purged: &purged})
+
+	w := httptest.NewRecorder()
+	e.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/api/admin/employee/1", nil))
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"authentication_required"`)
+	assert.Empty(t, purged)
+}
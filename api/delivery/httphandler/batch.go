@@ -0,0 +1,97 @@
+package httphandler
+
+import (
+	"employee-management/domain/dto"
+	"employee-management/utils/httputil"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// batchResponse is a dto.BatchResult with the errors of its failed
+// operations.
+type batchResponse struct {
+	*dto.BatchResult
+	Operations []batchOperationResponse `json:"operations"`
+}
+
+// batchOperationResponse reports a failed operation like the endpoint it
+// stands in for would: with its HTTP status and StandardErrors.
+type batchOperationResponse struct {
+	*dto.BatchOperationResult
+	HTTPStatus int                      `json:"http_status,omitempty"`
+	Errors     []httputil.StandardError `json:"errors,omitempty"`
+}
+
+// BatchEmployeesHandler runs a list of creates, updates and deletes in one
+// transaction. The response reports every operation, whether or not the
+// batch was committed.
+func (s *employeeHandler) BatchEmployeesHandler(ctx *gin.Context) {
+	var (
+		startTime = time.Now()
+		err       error
+	)
+	defer func() {
+		if err != nil {
+			writeError(ctx, err)
+		}
+	}()
+
+	req := new(dto.BatchRequest)
+	if err = ctx.ShouldBindJSON(req); err != nil {
+		err = invalidRequest(err)
+		return
+	}
+	for _, operation := range req.Operations {
+		operation.Precondition = dto.Precondition{IfMatch: httputil.ParseIfMatch(operation.IfMatch)}
+	}
+
+	result, err := s.employeeUsecase.BatchEmployees(ctx.Request.Context(), req)
+	if err != nil {
+		return
+	}
+	for _, operation := range result.Operations {
+		operation.Employee = maskEmployee(ctx, operation.Employee)
+	}
+
+	data, err := json.Marshal(httputil.StandardEnvelope{
+		Data: toBatchResponse(result),
+		Status: &httputil.StandardStatus{
+			Message:   http.StatusText(http.StatusOK),
+			ErrorCode: 0,
+		},
+		Header: &httputil.StandardHeader{
+			TotalData:   result.Total,
+			ProcessTime: time.Since(startTime).Seconds(),
+		},
+	})
+	if err != nil {
+		return
+	}
+	_, _ = httputil.WriteJSONResponse(ctx.Writer, data, http.StatusOK)
+}
+
+// toBatchResponse converts the errors of failed operations. Pointers into
+// an operation body are made relative to the batch request.
+func toBatchResponse(result *dto.BatchResult) batchResponse {
+	response := batchResponse{
+		BatchResult: result,
+		Operations:  make([]batchOperationResponse, 0, len(result.Operations)),
+	}
+	for _, operation := range result.Operations {
+		opResponse := batchOperationResponse{BatchOperationResult: operation}
+		if operation.Err != nil {
+			opResponse.HTTPStatus, opResponse.Errors = httputil.StandardErrors(operation.Err)
+			for i := range opResponse.Errors {
+				if pointer := opResponse.Errors[i].Object.Pointer; pointer != "" {
+					opResponse.Errors[i].Object.Pointer = fmt.Sprintf("/operations/%d/body%s", operation.Index, pointer)
+				}
+			}
+		}
+		response.Operations = append(response.Operations, opResponse)
+	}
+	return response
+}
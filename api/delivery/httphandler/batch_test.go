@@ -0,0 +1,30 @@
+package httphandler
+
+import (
+	"employee-management/domain/apperror"
+	"employee-management/domain/dto"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToBatchResponse(t *testing.T) {
+	id := 3
+	response := toBatchResponse(&dto.BatchResult{Operations: []*dto.BatchOperationResult{
+		{Index: 0, Op: dto.BatchDelete, Status: dto.BatchOperationSucceeded, ID: &id},
+		{Index: 1, Op: dto.BatchCreate, Status: dto.BatchOperationFailed, Err: apperror.ValidationFields("validation_failed", "request validation failed", []apperror.FieldError{
+			{Pointer: "/salary", Code: "field_required", Message: "salary is required"},
+		})},
+		{Index: 2, Op: dto.BatchUpdate, Status: dto.BatchOperationFailed, ID: &id, Err: apperror.NotFound("employee_not_found", "employee not found")},
+	}})
+
+	assert.Len(t, response.Operations, 3)
+	assert.Zero(t, response.Operations[0].HTTPStatus)
+	assert.Empty(t, response.Operations[0].Errors)
+	assert.Equal(t, http.StatusUnprocessableEntity, response.Operations[1].HTTPStatus)
+	assert.Equal(t, "/operations/1/body/salary", response.Operations[1].Errors[0].Object.Pointer)
+	assert.Equal(t, http.StatusNotFound, response.Operations[2].HTTPStatus)
+	assert.Equal(t, "employee_not_found", response.Operations[2].Errors[0].Code)
+	assert.Empty(t, response.Operations[2].Errors[0].Object.Pointer)
+}
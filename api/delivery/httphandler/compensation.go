@@ -0,0 +1,95 @@
+package httphandler
+
+import (
+	"employee-management/domain/dto"
+	"employee-management/utils/httputil"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (s *employeeHandler) ScheduleCompensationHandler(ctx *gin.Context) {
+	var (
+		startTime = time.Now()
+		err       error
+	)
+	defer func() {
+		if err != nil {
+			writeError(ctx, err)
+		}
+	}()
+
+	req := new(dto.GetEmployeeByIDRequest)
+	if err = ctx.ShouldBindUri(req); err != nil {
+		err = invalidRequest(err)
+		return
+	}
+
+	body := new(dto.ScheduleCompensationRequest)
+	if err = ctx.ShouldBindJSON(body); err != nil {
+		err = invalidRequest(err)
+		return
+	}
+
+	compensation, err := s.employeeUsecase.ScheduleCompensation(ctx.Request.Context(), req.EmployeeID, body)
+	if err != nil {
+		return
+	}
+
+	data, err := json.Marshal(httputil.StandardEnvelope{
+		Data: compensation,
+		Status: &httputil.StandardStatus{
+			Message:   http.StatusText(http.StatusCreated),
+			ErrorCode: 0,
+		},
+		Header: &httputil.StandardHeader{
+			TotalData:   1,
+			ProcessTime: time.Since(startTime).Seconds(),
+		},
+	})
+	if err != nil {
+		return
+	}
+	_, _ = httputil.WriteJSONResponse(ctx.Writer, data, http.StatusCreated)
+}
+
+func (s *employeeHandler) GetCompensationTimelineHandler(ctx *gin.Context) {
+	var (
+		startTime = time.Now()
+		err       error
+	)
+	defer func() {
+		if err != nil {
+			writeError(ctx, err)
+		}
+	}()
+
+	req := new(dto.GetEmployeeByIDRequest)
+	if err = ctx.ShouldBindUri(req); err != nil {
+		err = invalidRequest(err)
+		return
+	}
+
+	timeline, err := s.employeeUsecase.GetCompensationTimeline(ctx.Request.Context(), req.EmployeeID)
+	if err != nil {
+		return
+	}
+
+	data, err := json.Marshal(httputil.StandardEnvelope{
+		Data: timeline,
+		Status: &httputil.StandardStatus{
+			Message:   http.StatusText(http.StatusOK),
+			ErrorCode: 0,
+		},
+		Header: &httputil.StandardHeader{
+			TotalData:   len(timeline),
+			ProcessTime: time.Since(startTime).Seconds(),
+		},
+	})
+	if err != nil {
+		return
+	}
+	_, _ = httputil.WriteJSONResponse(ctx.Writer, data, http.StatusOK)
+}
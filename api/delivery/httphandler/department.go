@@ -0,0 +1,292 @@
+package httphandler
+
+import (
+	"employee-management/domain/dto"
+	"employee-management/domain/interfaces"
+	"employee-management/utils/httputil"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type departmentHandler struct {
+	departmentUsecase interfaces.DepartmentUsecase
+	employeeUsecase   interfaces.EmployeeUsecase
+}
+
+func NewDepartmentHandler(e gin.IRouter, d interfaces.DepartmentUsecase, a interfaces.EmployeeUsecase) {
+	handler := departmentHandler{departmentUsecase: d, employeeUsecase: a}
+	e.GET("api/departments", authorize(dto.PermissionDepartmentRead), handler.GetDepartmentsHandler)
+	e.POST("api/departments", authorize(dto.PermissionDepartmentWrite), handler.CreateDepartmentHandler)
+	e.GET("api/departments/:department_id", authorize(dto.PermissionDepartmentRead), handler.GetDepartmentByIdHandler)
+	e.PUT("api/departments/:department_id", authorize(dto.PermissionDepartmentWrite), handler.UpdateDepartmentHandler)
+	e.DELETE("api/departments/:department_id", authorize(dto.PermissionDepartmentWrite), handler.DeleteDepartmentHandler)
+	e.GET("api/departments/:department_id/employees", authorize(dto.PermissionEmployeeRead), handler.GetDepartmentEmployeesHandler)
+}
+
+func (s *departmentHandler) GetDepartmentByIdHandler(ctx *gin.Context) {
+	var (
+		startTime = time.Now()
+		err       error
+	)
+	defer func() {
+		if err != nil {
+			writeError(ctx, err)
+		}
+	}()
+
+	req := new(dto.GetDepartmentByIDRequest)
+	if err = ctx.ShouldBindUri(req); err != nil {
+		err = invalidRequest(err)
+		return
+	}
+
+	department, err := s.departmentUsecase.GetDepartmentByID(ctx.Request.Context(), req.DepartmentID)
+	if err != nil {
+		return
+	}
+
+	data, err := json.Marshal(httputil.StandardEnvelope{
+		Data: department,
+		Status: &httputil.StandardStatus{
+			Message:   http.StatusText(http.StatusOK),
+			ErrorCode: 0,
+		},
+		Header: &httputil.StandardHeader{
+			TotalData:   1,
+			ProcessTime: time.Since(startTime).Seconds(),
+		},
+	})
+	if err != nil {
+		return
+	}
+	_, _ = httputil.WriteJSONResponse(ctx.Writer, data, http.StatusOK)
+}
+
+func (s *departmentHandler) GetDepartmentsHandler(ctx *gin.Context) {
+	var (
+		startTime = time.Now()
+		err       error
+	)
+	defer func() {
+		if err != nil {
+			writeError(ctx, err)
+		}
+	}()
+
+	req := new(dto.GetDepartments)
+	if err = ctx.ShouldBindQuery(req); err != nil {
+		err = invalidRequest(err)
+		return
+	}
+
+	page, err := s.departmentUsecase.GetAllDepartments(ctx.Request.Context(), req)
+	if err != nil {
+		return
+	}
+
+	meta, links := paginationMeta(ctx.Request, page.PageInfo)
+	if len(links) > 0 {
+		ctx.Writer.Header().Set("Link", httputil.LinkHeader(links))
+	}
+
+	data, err := json.Marshal(httputil.StandardEnvelope{
+		Data: page.Departments,
+		Status: &httputil.StandardStatus{
+			Message:   http.StatusText(http.StatusOK),
+			ErrorCode: 0,
+		},
+		Header: &httputil.StandardHeader{
+			TotalData:   int(page.TotalCount),
+			ProcessTime: time.Since(startTime).Seconds(),
+			Meta:        meta,
+		},
+	})
+	if err != nil {
+		return
+	}
+	_, _ = httputil.WriteJSONResponse(ctx.Writer, data, http.StatusOK)
+}
+
+func (s *departmentHandler) CreateDepartmentHandler(ctx *gin.Context) {
+	var (
+		startTime = time.Now()
+		err       error
+	)
+	defer func() {
+		if err != nil {
+			writeError(ctx, err)
+		}
+	}()
+
+	req := new(dto.DepartmentRequest)
+	if err = ctx.ShouldBindJSON(req); err != nil {
+		err = invalidRequest(err)
+		return
+	}
+
+	department, err := s.departmentUsecase.CreateDepartment(ctx.Request.Context(), req)
+	if err != nil {
+		return
+	}
+
+	data, err := json.Marshal(httputil.StandardEnvelope{
+		Data: department,
+		Status: &httputil.StandardStatus{
+			Message:   http.StatusText(http.StatusCreated),
+			ErrorCode: 0,
+		},
+		Header: &httputil.StandardHeader{
+			TotalData:   1,
+			ProcessTime: time.Since(startTime).Seconds(),
+		},
+	})
+	if err != nil {
+		return
+	}
+	_, _ = httputil.WriteJSONResponse(ctx.Writer, data, http.StatusCreated)
+}
+
+func (s *departmentHandler) UpdateDepartmentHandler(ctx *gin.Context) {
+	var (
+		startTime = time.Now()
+		err       error
+	)
+	defer func() {
+		if err != nil {
+			writeError(ctx, err)
+		}
+	}()
+
+	req := new(dto.GetDepartmentByIDRequest)
+	if err = ctx.ShouldBindUri(req); err != nil {
+		err = invalidRequest(err)
+		return
+	}
+
+	reqBody := new(dto.DepartmentRequest)
+	if err = ctx.ShouldBindJSON(reqBody); err != nil {
+		err = invalidRequest(err)
+		return
+	}
+
+	department, err := s.departmentUsecase.UpdateDepartment(ctx.Request.Context(), req.DepartmentID, reqBody)
+	if err != nil {
+		return
+	}
+
+	data, err := json.Marshal(httputil.StandardEnvelope{
+		Data: department,
+		Status: &httputil.StandardStatus{
+			Message:   http.StatusText(http.StatusOK),
+			ErrorCode: 0,
+		},
+		Header: &httputil.StandardHeader{
+			TotalData:   1,
+			ProcessTime: time.Since(startTime).Seconds(),
+		},
+	})
+	if err != nil {
+		return
+	}
+	_, _ = httputil.WriteJSONResponse(ctx.Writer, data, http.StatusOK)
+}
+
+func (s *departmentHandler) DeleteDepartmentHandler(ctx *gin.Context) {
+	var (
+		startTime = time.Now()
+		err       error
+	)
+	defer func() {
+		if err != nil {
+			writeError(ctx, err)
+		}
+	}()
+
+	req := new(dto.GetDepartmentByIDRequest)
+	if err = ctx.ShouldBindUri(req); err != nil {
+		err = invalidRequest(err)
+		return
+	}
+
+	err = s.departmentUsecase.DeleteDepartment(ctx.Request.Context(), req.DepartmentID)
+	if err != nil {
+		return
+	}
+
+	data, err := json.Marshal(httputil.StandardEnvelope{
+		Data: "Department Deleted Successfully",
+		Status: &httputil.StandardStatus{
+			Message:   http.StatusText(http.StatusOK),
+			ErrorCode: 0,
+		},
+		Header: &httputil.StandardHeader{
+			TotalData:   1,
+			ProcessTime: time.Since(startTime).Seconds(),
+		},
+	})
+	if err != nil {
+		return
+	}
+	_, _ = httputil.WriteJSONResponse(ctx.Writer, data, http.StatusOK)
+}
+
+// GetDepartmentEmployeesHandler lists the employees of a department and
+// accepts the query parameters of the list employees endpoint.
+func (s *departmentHandler) GetDepartmentEmployeesHandler(ctx *gin.Context) {
+	var (
+		startTime = time.Now()
+		err       error
+	)
+	defer func() {
+		if err != nil {
+			writeError(ctx, err)
+		}
+	}()
+
+	req := new(dto.GetDepartmentByIDRequest)
+	if err = ctx.ShouldBindUri(req); err != nil {
+		err = invalidRequest(err)
+		return
+	}
+
+	query := new(dto.GetEmployee)
+	if err = ctx.ShouldBindQuery(query); err != nil {
+		err = invalidRequest(err)
+		return
+	}
+
+	page, err := s.employeeUsecase.GetDepartmentEmployees(ctx.Request.Context(), req.DepartmentID, query)
+	if err != nil {
+		return
+	}
+
+	meta, links := paginationMeta(ctx.Request, page.PageInfo)
+	if len(links) > 0 {
+		ctx.Writer.Header().Set("Link", httputil.LinkHeader(links))
+	}
+	totalData := int(page.TotalCount)
+	if page.Limit > 0 {
+		// keyset listings are not counted
+		totalData = len(page.Employees)
+	}
+
+	data, err := json.Marshal(httputil.StandardEnvelope{
+		Data: maskEmployees(ctx, page.Employees),
+		Status: &httputil.StandardStatus{
+			Message:   http.StatusText(http.StatusOK),
+			ErrorCode: 0,
+		},
+		Header: &httputil.StandardHeader{
+			TotalData:   totalData,
+			ProcessTime: time.Since(startTime).Seconds(),
+			Meta:        meta,
+		},
+	})
+	if err != nil {
+		return
+	}
+	_, _ = httputil.WriteJSONResponse(ctx.Writer, data, http.StatusOK)
+}
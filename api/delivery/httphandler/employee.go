@@ -1,67 +1,69 @@
 package httphandler
 
 import (
+	"employee-management/domain/apperror"
 	"employee-management/domain/dto"
 	"employee-management/domain/interfaces"
 	"employee-management/utils/httputil"
 	"encoding/json"
-	"fmt"
 	"net/http"
-	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 )
 
 type employeeHandler struct {
 	employeeUsecase interfaces.EmployeeUsecase
 }
 
-func NewEmployeeHandler(e *gin.Engine, a interfaces.EmployeeUsecase) {
+func NewEmployeeHandler(e gin.IRouter, a interfaces.EmployeeUsecase) {
 	handler := employeeHandler{employeeUsecase: a}
-	fmt.Println("handler", handler)
-	e.GET("api/employee/:employee_id", handler.GetEmployeeByIdHandler)
-	e.GET("api/list_employee", handler.GetEmployeeHandler)
-	e.POST("api/add-employee", handler.CreateEmployeeHandler)
-	e.PUT("api/employee/:employee_id", handler.UpdateEmployeeHandler)
-	e.DELETE("api/employee/:employee_id", handler.DeleteEmployeeHandler)
+	e.GET("api/employee/:employee_id", authorize(dto.PermissionEmployeeRead), handler.GetEmployeeByIdHandler)
+	e.GET("api/list_employee", authorize(dto.PermissionEmployeeRead), handler.GetEmployeeHandler)
+	e.GET("api/employee/export", authorize(dto.PermissionEmployeeRead), handler.ExportEmployeesHandler)
+	e.GET("api/employee/search", authorize(dto.PermissionEmployeeRead), handler.SearchEmployeesHandler)
+	e.GET("api/employees/stats", authorize(dto.PermissionSalaryRead), handler.GetEmployeeStatsHandler)
+	e.POST("api/add-employee", authorize(dto.PermissionEmployeeWrite), handler.CreateEmployeeHandler)
+	e.POST("api/employee/import", authorize(dto.PermissionEmployeeWrite), handler.ImportEmployeesHandler)
+	e.POST("api/employee/batch", authorize(dto.PermissionEmployeeWrite), handler.BatchEmployeesHandler)
+	e.PUT("api/employee/:employee_id", authorize(dto.PermissionEmployeeWrite), handler.UpdateEmployeeHandler)
+	e.PATCH("api/employee/:employee_id", authorize(dto.PermissionEmployeeWrite), handler.PatchEmployeeHandler)
+	e.DELETE("api/employee/:employee_id", authorize(dto.PermissionEmployeeWrite), handler.DeleteEmployeeHandler)
+	e.POST("api/employee/:employee_id/restore", authorize(dto.PermissionEmployeeWrite), handler.RestoreEmployeeHandler)
+	e.GET("api/employee/:employee_id/history", authorize(dto.PermissionEmployeeRead), handler.GetEmployeeHistoryHandler)
+	e.POST("api/employee/:employee_id/compensation", authorize(dto.PermissionSalaryWrite), handler.ScheduleCompensationHandler)
+	e.GET("api/employee/:employee_id/compensation", authorize(dto.PermissionSalaryRead), handler.GetCompensationTimelineHandler)
+	e.GET("api/employee/:employee_id/reports", authorize(dto.PermissionEmployeeRead), handler.GetDirectReportsHandler)
+	e.GET("api/employee/:employee_id/reporting_chain", authorize(dto.PermissionEmployeeRead), handler.GetReportingChainHandler)
+	e.GET("api/employee/:employee_id/org_chart", authorize(dto.PermissionEmployeeRead), handler.GetOrgChartHandler)
 }
 
 func (s *employeeHandler) GetEmployeeByIdHandler(ctx *gin.Context) {
 	var (
 		startTime = time.Now()
-		httpError *httputil.StandardError
+		err       error
 	)
 	defer func() {
-		if httpError != nil {
-			errCode, _ := strconv.Atoi(httpError.Code)
-			httputil.WriteErrorResponse(ctx.Writer, errCode, []httputil.StandardError{*httpError})
+		if err != nil {
+			writeError(ctx, err)
 		}
 	}()
 
 	req := new(dto.GetEmployeeByIDRequest)
-
-	if err := ctx.ShouldBindUri(req); err != nil {
-		httpError = &httputil.StandardError{
-			Code:   strconv.Itoa(http.StatusBadRequest),
-			Title:  http.StatusText(http.StatusBadRequest),
-			Detail: err.Error(),
-		}
+	if err = ctx.ShouldBindUri(req); err != nil {
+		err = invalidRequest(err)
 		return
 	}
 
-	employee, err := s.employeeUsecase.GetEmployeeById(ctx, req.EmployeeID)
+	employee, err := s.employeeUsecase.GetEmployeeById(ctx.Request.Context(), req.EmployeeID)
 	if err != nil {
-		httpError = &httputil.StandardError{
-			Code:   strconv.Itoa(http.StatusInternalServerError),
-			Title:  http.StatusText(http.StatusInternalServerError),
-			Detail: err.Error(),
-		}
 		return
 	}
+	ctx.Header("ETag", httputil.ETag(employee.Version()))
 
 	data, err := json.Marshal(httputil.StandardEnvelope{
-		Data: employee,
+		Data: maskEmployee(ctx, employee),
 		Status: &httputil.StandardStatus{
 			Message:   http.StatusText(http.StatusOK),
 			ErrorCode: 0,
@@ -72,111 +74,87 @@ func (s *employeeHandler) GetEmployeeByIdHandler(ctx *gin.Context) {
 		},
 	})
 	if err != nil {
-		httpError = &httputil.StandardError{
-			Code:   strconv.Itoa(http.StatusInternalServerError),
-			Title:  http.StatusText(http.StatusInternalServerError),
-			Detail: err.Error(),
-		}
 		return
 	}
 	_, _ = httputil.WriteJSONResponse(ctx.Writer, data, http.StatusOK)
-	return
 }
 
 func (s *employeeHandler) GetEmployeeHandler(ctx *gin.Context) {
 	var (
 		startTime = time.Now()
-		httpError *httputil.StandardError
+		err       error
 	)
 	defer func() {
-		if httpError != nil {
-			errCode, _ := strconv.Atoi(httpError.Code)
-			httputil.WriteErrorResponse(ctx.Writer, errCode, []httputil.StandardError{*httpError})
+		if err != nil {
+			writeError(ctx, err)
 		}
 	}()
 
 	req := new(dto.GetEmployee)
-	if err := ctx.ShouldBindQuery(&req); err != nil {
-		httpError = &httputil.StandardError{
-			Code:   strconv.Itoa(http.StatusBadRequest),
-			Title:  http.StatusText(http.StatusBadRequest),
-			Detail: err.Error(),
-		}
+	if err = ctx.ShouldBindQuery(req); err != nil {
+		err = invalidRequest(err)
 		return
 	}
 
-	if req.PageSize == 0 {
-		req.PageSize = 100
-	}
-	offset := (req.Page - 1) * req.PageSize
-	limit := req.PageSize
-	fmt.Println("offset", offset)
-
-	employee, err := s.employeeUsecase.GetAllEmployee(ctx, limit, offset)
+	page, err := s.employeeUsecase.GetAllEmployee(ctx.Request.Context(), req)
 	if err != nil {
-		httpError = &httputil.StandardError{
-			Code:   strconv.Itoa(http.StatusInternalServerError),
-			Title:  http.StatusText(http.StatusInternalServerError),
-			Detail: err.Error(),
-		}
 		return
 	}
 
+	meta, links := paginationMeta(ctx.Request, page.PageInfo)
+	if len(links) > 0 {
+		ctx.Writer.Header().Set("Link", httputil.LinkHeader(links))
+	}
+	totalData := int(page.TotalCount)
+	if page.Limit > 0 {
+		// keyset listings are not counted
+		totalData = len(page.Employees)
+	}
+
 	data, err := json.Marshal(httputil.StandardEnvelope{
-		Data: employee,
+		Data: maskEmployees(ctx, page.Employees),
 		Status: &httputil.StandardStatus{
 			Message:   http.StatusText(http.StatusOK),
 			ErrorCode: 0,
 		},
 		Header: &httputil.StandardHeader{
-			TotalData:   1,
+			TotalData:   totalData,
 			ProcessTime: time.Since(startTime).Seconds(),
+			Meta:        meta,
 		},
 	})
 	if err != nil {
-		httpError = &httputil.StandardError{
-			Code:   strconv.Itoa(http.StatusInternalServerError),
-			Title:  http.StatusText(http.StatusInternalServerError),
-			Detail: err.Error(),
-		}
 		return
 	}
 
 	_, _ = httputil.WriteJSONResponse(ctx.Writer, data, http.StatusOK)
-	return
 }
 
 func (s *employeeHandler) CreateEmployeeHandler(ctx *gin.Context) {
 	var (
 		startTime = time.Now()
-		httpError *httputil.StandardError
+		err       error
 	)
 	defer func() {
-		if httpError != nil {
-			errCode, _ := strconv.Atoi(httpError.Code)
-			httputil.WriteErrorResponse(ctx.Writer, errCode, []httputil.StandardError{*httpError})
+		if err != nil {
+			writeError(ctx, err)
 		}
 	}()
 
 	req := new(dto.EmployeeCreateRequest)
-	if err := ctx.ShouldBindJSON(&req); err != nil {
-		httpError = &httputil.StandardError{
-			Code:   strconv.Itoa(http.StatusBadRequest),
-			Title:  http.StatusText(http.StatusBadRequest),
-			Detail: err.Error(),
-		}
+	if err = ctx.ShouldBindJSON(req); err != nil {
+		err = invalidRequest(err)
 		return
 	}
+	req.IdempotencyKey = ctx.GetHeader("Idempotency-Key")
 
-	resp, err := s.employeeUsecase.CreateEmployee(ctx, req)
+	resp, err := s.employeeUsecase.CreateEmployee(ctx.Request.Context(), req)
 	if err != nil {
-		httpError = &httputil.StandardError{
-			Code:   strconv.Itoa(http.StatusInternalServerError),
-			Title:  http.StatusText(http.StatusInternalServerError),
-			Detail: err.Error(),
-		}
 		return
 	}
+	if resp.Replayed {
+		ctx.Header("Idempotent-Replayed", "true")
+	}
 
 	data, err := json.Marshal(httputil.StandardEnvelope{
 		Data: resp,
@@ -190,61 +168,42 @@ func (s *employeeHandler) CreateEmployeeHandler(ctx *gin.Context) {
 		},
 	})
 	if err != nil {
-		httpError = &httputil.StandardError{
-			Code:   strconv.Itoa(http.StatusInternalServerError),
-			Title:  http.StatusText(http.StatusInternalServerError),
-			Detail: err.Error(),
-		}
 		return
 	}
 	_, _ = httputil.WriteJSONResponse(ctx.Writer, data, http.StatusCreated)
-	return
 }
 
 func (s *employeeHandler) UpdateEmployeeHandler(ctx *gin.Context) {
 	var (
 		startTime = time.Now()
-		httpError *httputil.StandardError
+		err       error
 	)
 	defer func() {
-		if httpError != nil {
-			errCode, _ := strconv.Atoi(httpError.Code)
-			httputil.WriteErrorResponse(ctx.Writer, errCode, []httputil.StandardError{*httpError})
+		if err != nil {
+			writeError(ctx, err)
 		}
 	}()
 
 	req := new(dto.UpdateEmployeeRequest)
-	if err := ctx.BindUri(req); err != nil {
-		httpError = &httputil.StandardError{
-			Code:   strconv.Itoa(http.StatusBadRequest),
-			Title:  http.StatusText(http.StatusBadRequest),
-			Detail: err.Error(),
-		}
+	if err = ctx.ShouldBindUri(req); err != nil {
+		err = invalidRequest(err)
 		return
 	}
 
 	reqBody := new(dto.UpdateEmployeeBodyRequest)
-	if err := ctx.Bind(&reqBody); err != nil {
-		httpError = &httputil.StandardError{
-			Code:   strconv.Itoa(http.StatusBadRequest),
-			Title:  http.StatusText(http.StatusBadRequest),
-			Detail: err.Error(),
-		}
+	if err = ctx.ShouldBind(reqBody); err != nil {
+		err = invalidRequest(err)
 		return
 	}
 
-	resp, err := s.employeeUsecase.UpdateEmployee(ctx, req.EmployeeID, reqBody)
+	resp, err := s.employeeUsecase.UpdateEmployee(ctx.Request.Context(), req.EmployeeID, reqBody, precondition(ctx))
 	if err != nil {
-		httpError = &httputil.StandardError{
-			Code:   strconv.Itoa(http.StatusInternalServerError),
-			Title:  http.StatusText(http.StatusInternalServerError),
-			Detail: err.Error(),
-		}
 		return
 	}
+	ctx.Header("ETag", httputil.ETag(resp.Version()))
 
 	data, err := json.Marshal(httputil.StandardEnvelope{
-		Data: resp,
+		Data: maskEmployee(ctx, resp),
 		Status: &httputil.StandardStatus{
 			Message:   http.StatusText(http.StatusOK),
 			ErrorCode: 0,
@@ -255,44 +214,88 @@ func (s *employeeHandler) UpdateEmployeeHandler(ctx *gin.Context) {
 		},
 	})
 	if err != nil {
-		httpError = &httputil.StandardError{
-			Code:   strconv.Itoa(http.StatusInternalServerError),
-			Title:  http.StatusText(http.StatusInternalServerError),
-			Detail: err.Error(),
+		return
+	}
+	_, _ = httputil.WriteJSONResponse(ctx.Writer, data, http.StatusOK)
+}
+
+// PatchEmployeeHandler accepts an RFC 7396 merge patch, also when sent as
+// plain application/json, or an RFC 6902 JSON patch.
+func (s *employeeHandler) PatchEmployeeHandler(ctx *gin.Context) {
+	var (
+		startTime = time.Now()
+		err       error
+	)
+	defer func() {
+		if err != nil {
+			writeError(ctx, err)
 		}
+	}()
+
+	req := new(dto.UpdateEmployeeRequest)
+	if err = ctx.ShouldBindUri(req); err != nil {
+		err = invalidRequest(err)
+		return
+	}
+
+	patch := dto.EmployeePatch{}
+	switch ctx.ContentType() {
+	case "application/merge-patch+json", binding.MIMEJSON:
+		patch.Format = dto.PatchMerge
+	case "application/json-patch+json":
+		patch.Format = dto.PatchJSON
+	default:
+		err = apperror.New(apperror.KindUnsupportedMediaType, "unsupported_media_type",
+			"content type must be application/merge-patch+json or application/json-patch+json")
+		return
+	}
+	if patch.Document, err = ctx.GetRawData(); err != nil {
+		err = invalidRequest(err)
+		return
+	}
+
+	resp, err := s.employeeUsecase.PatchEmployee(ctx.Request.Context(), req.EmployeeID, patch, precondition(ctx))
+	if err != nil {
+		return
+	}
+	ctx.Header("ETag", httputil.ETag(resp.Version()))
+
+	data, err := json.Marshal(httputil.StandardEnvelope{
+		Data: maskEmployee(ctx, resp),
+		Status: &httputil.StandardStatus{
+			Message:   http.StatusText(http.StatusOK),
+			ErrorCode: 0,
+		},
+		Header: &httputil.StandardHeader{
+			TotalData:   1,
+			ProcessTime: time.Since(startTime).Seconds(),
+		},
+	})
+	if err != nil {
 		return
 	}
 	_, _ = httputil.WriteJSONResponse(ctx.Writer, data, http.StatusOK)
-	return
 }
 
 func (s *employeeHandler) DeleteEmployeeHandler(ctx *gin.Context) {
 	var (
 		startTime = time.Now()
-		httpError *httputil.StandardError
+		err       error
 	)
 	defer func() {
-		if httpError != nil {
-			errCode, _ := strconv.Atoi(httpError.Code)
-			httputil.WriteErrorResponse(ctx.Writer, errCode, []httputil.StandardError{*httpError})
+		if err != nil {
+			writeError(ctx, err)
 		}
 	}()
+
 	req := new(dto.DeleteEmployeeRequest)
-	if err := ctx.ShouldBindUri(req); err != nil {
-		httpError = &httputil.StandardError{
-			Code:   strconv.Itoa(http.StatusBadRequest),
-			Title:  http.StatusText(http.StatusBadRequest),
-			Detail: err.Error(),
-		}
+	if err = ctx.ShouldBindUri(req); err != nil {
+		err = invalidRequest(err)
 		return
 	}
-	err := s.employeeUsecase.DeleteEmployee(ctx, req.EmployeeID)
+
+	err = s.employeeUsecase.DeleteEmployee(ctx.Request.Context(), req.EmployeeID, precondition(ctx))
 	if err != nil {
-		httpError = &httputil.StandardError{
-			Code:   strconv.Itoa(http.StatusInternalServerError),
-			Title:  http.StatusText(http.StatusInternalServerError),
-			Detail: err.Error(),
-		}
 		return
 	}
 
@@ -308,13 +311,98 @@ func (s *employeeHandler) DeleteEmployeeHandler(ctx *gin.Context) {
 		},
 	})
 	if err != nil {
-		httpError = &httputil.StandardError{
-			Code:   strconv.Itoa(http.StatusInternalServerError),
-			Title:  http.StatusText(http.StatusInternalServerError),
-			Detail: err.Error(),
+		return
+	}
+	_, _ = httputil.WriteJSONResponse(ctx.Writer, data, http.StatusOK)
+}
+
+func (s *employeeHandler) RestoreEmployeeHandler(ctx *gin.Context) {
+	var (
+		startTime = time.Now()
+		err       error
+	)
+	defer func() {
+		if err != nil {
+			writeError(ctx, err)
+		}
+	}()
+
+	req := new(dto.GetEmployeeByIDRequest)
+	if err = ctx.ShouldBindUri(req); err != nil {
+		err = invalidRequest(err)
+		return
+	}
+
+	employee, err := s.employeeUsecase.RestoreEmployee(ctx.Request.Context(), req.EmployeeID, precondition(ctx))
+	if err != nil {
+		return
+	}
+	ctx.Header("ETag", httputil.ETag(employee.Version()))
+
+	data, err := json.Marshal(httputil.StandardEnvelope{
+		Data: maskEmployee(ctx, employee),
+		Status: &httputil.StandardStatus{
+			Message:   http.StatusText(http.StatusOK),
+			ErrorCode: 0,
+		},
+		Header: &httputil.StandardHeader{
+			TotalData:   1,
+			ProcessTime: time.Since(startTime).Seconds(),
+		},
+	})
+	if err != nil {
+		return
+	}
+	_, _ = httputil.WriteJSONResponse(ctx.Writer, data, http.StatusOK)
+}
+
+func (s *employeeHandler) GetEmployeeHistoryHandler(ctx *gin.Context) {
+	var (
+		startTime = time.Now()
+		err       error
+	)
+	defer func() {
+		if err != nil {
+			writeError(ctx, err)
 		}
+	}()
+
+	req := new(dto.GetEmployeeByIDRequest)
+	if err = ctx.ShouldBindUri(req); err != nil {
+		err = invalidRequest(err)
+		return
+	}
+
+	query := new(dto.GetEmployeeHistory)
+	if err = ctx.ShouldBindQuery(query); err != nil {
+		err = invalidRequest(err)
+		return
+	}
+
+	page, err := s.employeeUsecase.GetEmployeeHistory(ctx.Request.Context(), req.EmployeeID, query)
+	if err != nil {
+		return
+	}
+
+	meta, links := paginationMeta(ctx.Request, page.PageInfo)
+	if len(links) > 0 {
+		ctx.Writer.Header().Set("Link", httputil.LinkHeader(links))
+	}
+
+	data, err := json.Marshal(httputil.StandardEnvelope{
+		Data: maskHistory(ctx, page.Entries),
+		Status: &httputil.StandardStatus{
+			Message:   http.StatusText(http.StatusOK),
+			ErrorCode: 0,
+		},
+		Header: &httputil.StandardHeader{
+			TotalData:   int(page.TotalCount),
+			ProcessTime: time.Since(startTime).Seconds(),
+			Meta:        meta,
+		},
+	})
+	if err != nil {
 		return
 	}
 	_, _ = httputil.WriteJSONResponse(ctx.Writer, data, http.StatusOK)
-	return
 }
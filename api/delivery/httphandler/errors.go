@@ -0,0 +1,25 @@
+package httphandler
+
+import (
+	"employee-management/domain/apperror"
+	"employee-management/utils/httputil"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// writeError writes err in the StandardEnvelope error format.
+func writeError(ctx *gin.Context, err error) {
+	if httputil.WriteError(ctx.Writer, err) == http.StatusInternalServerError {
+		// the response hides the cause, the request log records it
+		_ = ctx.Error(err)
+	}
+}
+
+// invalidRequest wraps a binding error from gin.
+func invalidRequest(err error) error {
+	if _, ok := apperror.As(err); ok {
+		return err
+	}
+	return apperror.Wrap(err, apperror.KindInvalidInput, "invalid_request", err.Error())
+}
@@ -0,0 +1,237 @@
+package httphandler
+
+import (
+	"bufio"
+	"employee-management/domain/apperror"
+	"employee-management/domain/dto"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	mimeCSV    = "text/csv"
+	mimeNDJSON = "application/x-ndjson"
+
+	// exportFlushRows is the number of rows written between two flushes.
+	exportFlushRows = 100
+)
+
+// exportFormats maps the format query parameter onto a media type.
+var exportFormats = map[string]string{
+	"csv":    mimeCSV,
+	"ndjson": mimeNDJSON,
+}
+
+// exportColumns is the header row of a CSV export.
+var exportColumns = []string{"id", "name", "position", "salary", "department_id", "manager_id", "created_at", "updated_at", "deleted_at"}
+
+// ExportEmployeesHandler streams every employee matching the filters and
+// sort of the list endpoint as CSV or NDJSON. The format is negotiated
+// from the Accept header unless the format query parameter names one.
+// Rows are written as they are read from the database, so a failure after
+// the first row can only be reported by cutting the response short.
+func (s *employeeHandler) ExportEmployeesHandler(ctx *gin.Context) {
+	var (
+		err    error
+		stream *exportStream
+	)
+	defer func() {
+		switch {
+		case err == nil:
+		case stream != nil && stream.started:
+			_ = ctx.Error(err)
+			abortStream(ctx)
+		default:
+			writeError(ctx, err)
+		}
+	}()
+
+	req := new(dto.GetEmployee)
+	if err = ctx.ShouldBindQuery(req); err != nil {
+		err = invalidRequest(err)
+		return
+	}
+
+	format, err := exportFormat(ctx)
+	if err != nil {
+		return
+	}
+
+	stream = newExportStream(ctx, format)
+	if err = s.employeeUsecase.ExportEmployees(ctx.Request.Context(), req, stream.write); err != nil {
+		return
+	}
+	err = stream.close()
+}
+
+// exportFormat returns the media type requested by ctx.
+func exportFormat(ctx *gin.Context) (string, error) {
+	if name, ok := ctx.GetQuery("format"); ok {
+		format, ok := exportFormats[strings.ToLower(name)]
+		if !ok {
+			return "", apperror.ValidationFields("validation_failed", "request validation failed", []apperror.FieldError{{
+				Pointer: "/format",
+				Code:    "field_invalid",
+				Message: "format must be csv or ndjson",
+			}})
+		}
+		return format, nil
+	}
+
+	format := ctx.NegotiateFormat(mimeCSV, mimeNDJSON)
+	if format == "" {
+		return "", apperror.New(apperror.KindNotAcceptable, "not_acceptable", "an export is available as text/csv or application/x-ndjson")
+	}
+	return format, nil
+}
+
+// exportStream writes employees to the response in one format. The
+// response headers are only written with the first row, so that errors
+// found before it can still be reported as a JSON error.
+type exportStream struct {
+	ctx     *gin.Context
+	format  string
+	started bool
+	rows    int
+	buf     *bufio.Writer
+	csv     *csv.Writer
+}
+
+func newExportStream(ctx *gin.Context, format string) *exportStream {
+	return &exportStream{ctx: ctx, format: format}
+}
+
+func (s *exportStream) start() error {
+	s.started = true
+
+	extension := "csv"
+	if s.format == mimeNDJSON {
+		extension = "ndjson"
+	}
+	header := s.ctx.Writer.Header()
+	header.Set("Content-Type", s.format+"; charset=utf-8")
+	header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=employees.%s", extension))
+	s.ctx.Status(http.StatusOK)
+
+	s.buf = bufio.NewWriter(s.ctx.Writer)
+	if s.format == mimeCSV {
+		s.csv = csv.NewWriter(s.buf)
+		return s.csv.Write(exportColumns)
+	}
+	return nil
+}
+
+func (s *exportStream) write(employee *dto.Employee) error {
+	if !s.started {
+		if err := s.start(); err != nil {
+			return err
+		}
+	}
+	employee = maskEmployee(s.ctx, employee)
+
+	if s.csv != nil {
+		if err := s.csv.Write(employeeRecord(employee)); err != nil {
+			return err
+		}
+	} else {
+		line, err := json.Marshal(employee)
+		if err != nil {
+			return err
+		}
+		if _, err = s.buf.Write(append(line, '\n')); err != nil {
+			return err
+		}
+	}
+
+	s.rows++
+	if s.rows%exportFlushRows == 0 {
+		return s.flush()
+	}
+	return nil
+}
+
+func (s *exportStream) flush() error {
+	if s.csv != nil {
+		s.csv.Flush()
+		if err := s.csv.Error(); err != nil {
+			return err
+		}
+	}
+	if err := s.buf.Flush(); err != nil {
+		return err
+	}
+	s.ctx.Writer.Flush()
+	return nil
+}
+
+// close ends the export; an export without rows still gets its headers.
+func (s *exportStream) close() error {
+	if !s.started {
+		if err := s.start(); err != nil {
+			return err
+		}
+	}
+	return s.flush()
+}
+
+// employeeRecord returns the CSV row of employee, in exportColumns order.
+func employeeRecord(employee *dto.Employee) []string {
+	return []string{
+		strconv.Itoa(employee.ID),
+		csvText(employee.Name),
+		csvText(employee.Position),
+		csvSalary(employee.Salary),
+		csvInt(employee.DepartmentID),
+		csvInt(employee.ManagerID),
+		employee.CreatedAt.Format(time.RFC3339Nano),
+		employee.UpdatedAt.Format(time.RFC3339Nano),
+		csvTime(employee.DeletedAt),
+	}
+}
+
+// csvText keeps spreadsheets from evaluating free text as a formula.
+func csvText(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// csvSalary leaves the salary of a masked employee empty.
+func csvSalary(value float64) string {
+	if value == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func csvInt(value *int) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.Itoa(*value)
+}
+
+func csvTime(value *time.Time) string {
+	if value == nil {
+		return ""
+	}
+	return value.Format(time.RFC3339Nano)
+}
+
+// abortStream closes the connection of a response that is already under
+// way, so that the client sees a truncated body instead of a complete one.
+func abortStream(ctx *gin.Context) {
+	conn, _, err := ctx.Writer.Hijack()
+	if err != nil {
+		return
+	}
+	_ = conn.Close()
+}
@@ -0,0 +1,74 @@
+package httphandler
+
+import (
+	"context"
+	"employee-management/domain/dto"
+	"employee-management/domain/interfaces"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// exportUsecase serves a fixed list of employees to ExportEmployees.
+type exportUsecase struct {
+	interfaces.EmployeeUsecase
+	employees []*dto.Employee
+}
+
+func (u exportUsecase) ExportEmployees(ctx context.Context, request *dto.GetEmployee, fn func(*dto.Employee) error) error {
+	for _, e := range u.employees {
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func serveExport(t *testing.T, target, accept string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	engineering := 7
+
+	e := gin.New()
+	NewEmployeeHandler(e, exportUsecase{employees: []*dto.Employee{
+		{ID: 1, Name: "=HYPERLINK()", Position: "Developer", Salary: 60000.5, DepartmentID: &engineering, CreatedAt: createdAt, UpdatedAt: createdAt},
+	}})
+
+	r := httptest.NewRequest(http.MethodGet, target, nil)
+	if accept != "" {
+		r.Header.Set("Accept", accept)
+	}
+	w := httptest.NewRecorder()
+	e.ServeHTTP(w, r)
+	return w
+}
+
+func TestExportEmployeesCSV(t *testing.T) {
+	w := serveExport(t, "/api/employee/export", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "attachment; filename=employees.csv", w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "id,name,position,salary,department_id,manager_id,created_at,updated_at,deleted_at\n"+
+		"1,'=HYPERLINK(),Developer,60000.5,7,,2024-01-02T03:04:05Z,2024-01-02T03:04:05Z,\n", w.Body.String())
+}
+
+func TestExportEmployeesNDJSON(t *testing.T) {
+	w := serveExport(t, "/api/employee/export?format=ndjson", "text/csv")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/x-ndjson; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, `{"id":1,"name":"=HYPERLINK()","position":"Developer","salary":60000.5,"created_at":"2024-01-02T03:04:05Z","updated_at":"2024-01-02T03:04:05Z","department_id":7,"manager_id":null}`+"\n", w.Body.String())
+}
+
+func TestExportEmployeesNotAcceptable(t *testing.T) {
+	w := serveExport(t, "/api/employee/export", "application/xml")
+
+	assert.Equal(t, http.StatusNotAcceptable, w.Code)
+	assert.Contains(t, w.Body.String(), "not_acceptable")
+}
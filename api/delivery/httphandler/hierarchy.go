@@ -0,0 +1,100 @@
+package httphandler
+
+import (
+	"context"
+	"employee-management/domain/dto"
+	"employee-management/utils/httputil"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (s *employeeHandler) GetDirectReportsHandler(ctx *gin.Context) {
+	s.writeEmployeeList(ctx, s.employeeUsecase.GetDirectReports)
+}
+
+func (s *employeeHandler) GetReportingChainHandler(ctx *gin.Context) {
+	s.writeEmployeeList(ctx, s.employeeUsecase.GetReportingChain)
+}
+
+// writeEmployeeList responds with the employees that list returns for the
+// employee in the path.
+func (s *employeeHandler) writeEmployeeList(ctx *gin.Context, list func(ctx context.Context, employeeID int) ([]*dto.Employee, error)) {
+	var (
+		startTime = time.Now()
+		err       error
+	)
+	defer func() {
+		if err != nil {
+			writeError(ctx, err)
+		}
+	}()
+
+	req := new(dto.GetEmployeeByIDRequest)
+	if err = ctx.ShouldBindUri(req); err != nil {
+		err = invalidRequest(err)
+		return
+	}
+
+	employees, err := list(ctx.Request.Context(), req.EmployeeID)
+	if err != nil {
+		return
+	}
+
+	data, err := json.Marshal(httputil.StandardEnvelope{
+		Data: maskEmployees(ctx, employees),
+		Status: &httputil.StandardStatus{
+			Message:   http.StatusText(http.StatusOK),
+			ErrorCode: 0,
+		},
+		Header: &httputil.StandardHeader{
+			TotalData:   len(employees),
+			ProcessTime: time.Since(startTime).Seconds(),
+		},
+	})
+	if err != nil {
+		return
+	}
+	_, _ = httputil.WriteJSONResponse(ctx.Writer, data, http.StatusOK)
+}
+
+func (s *employeeHandler) GetOrgChartHandler(ctx *gin.Context) {
+	var (
+		startTime = time.Now()
+		err       error
+	)
+	defer func() {
+		if err != nil {
+			writeError(ctx, err)
+		}
+	}()
+
+	req := new(dto.GetEmployeeByIDRequest)
+	if err = ctx.ShouldBindUri(req); err != nil {
+		err = invalidRequest(err)
+		return
+	}
+
+	chart, err := s.employeeUsecase.GetOrgChart(ctx.Request.Context(), req.EmployeeID)
+	if err != nil {
+		return
+	}
+
+	data, err := json.Marshal(httputil.StandardEnvelope{
+		Data: maskOrgChart(ctx, chart),
+		Status: &httputil.StandardStatus{
+			Message:   http.StatusText(http.StatusOK),
+			ErrorCode: 0,
+		},
+		Header: &httputil.StandardHeader{
+			TotalData:   1,
+			ProcessTime: time.Since(startTime).Seconds(),
+		},
+	})
+	if err != nil {
+		return
+	}
+	_, _ = httputil.WriteJSONResponse(ctx.Writer, data, http.StatusOK)
+}
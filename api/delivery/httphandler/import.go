@@ -0,0 +1,75 @@
+package httphandler
+
+import (
+	"employee-management/domain/apperror"
+	"employee-management/domain/dto"
+	"employee-management/utils/httputil"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// ImportEmployeesHandler creates employees from the CSV file uploaded in
+// the "file" field of a multipart form. The response reports every row,
+// whether or not the import was committed.
+func (s *employeeHandler) ImportEmployeesHandler(ctx *gin.Context) {
+	var (
+		startTime = time.Now()
+		err       error
+	)
+	defer func() {
+		if err != nil {
+			writeError(ctx, err)
+		}
+	}()
+
+	header, err := ctx.FormFile("file")
+	switch {
+	case errors.Is(err, http.ErrNotMultipart):
+		err = apperror.Wrap(err, apperror.KindUnsupportedMediaType, "unsupported_media_type", "the request must be multipart/form-data")
+		return
+	case errors.Is(err, http.ErrMissingFile):
+		err = apperror.Wrap(err, apperror.KindInvalidInput, "invalid_request", "the request must upload the CSV file in the file field")
+		return
+	case err != nil:
+		err = invalidRequest(err)
+		return
+	}
+
+	req := new(dto.ImportEmployeesRequest)
+	if err = ctx.ShouldBindWith(req, binding.FormMultipart); err != nil {
+		err = invalidRequest(err)
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	report, err := s.employeeUsecase.ImportEmployees(ctx.Request.Context(), file, req)
+	if err != nil {
+		return
+	}
+
+	data, err := json.Marshal(httputil.StandardEnvelope{
+		Data: report,
+		Status: &httputil.StandardStatus{
+			Message:   http.StatusText(http.StatusOK),
+			ErrorCode: 0,
+		},
+		Header: &httputil.StandardHeader{
+			TotalData:   report.Total,
+			ProcessTime: time.Since(startTime).Seconds(),
+		},
+	})
+	if err != nil {
+		return
+	}
+	_, _ = httputil.WriteJSONResponse(ctx.Writer, data, http.StatusOK)
+}
@@ -0,0 +1,61 @@
+package httphandler
+
+import (
+	"employee-management/domain/dto"
+	"employee-management/utils/httputil"
+	"net/http"
+)
+
+// paginationMeta describes page for the envelope header and returns the
+// matching navigation links.
+func paginationMeta(r *http.Request, page dto.PageInfo) (map[string]interface{}, []httputil.Link) {
+	if page.Limit > 0 {
+		return cursorMeta(r, page)
+	}
+
+	meta := map[string]interface{}{
+		"page":        page.Page,
+		"page_size":   page.PageSize,
+		"total_count": page.TotalCount,
+		"total_pages": page.TotalPages,
+		"next":        nil,
+		"prev":        nil,
+	}
+	if page.TotalPages == 0 {
+		return meta, nil
+	}
+
+	links := []httputil.Link{{URL: httputil.PageURL(r, 1), Rel: "first"}}
+	if page.Page > 1 {
+		// a page past the end points back at the last page
+		prev := httputil.PageURL(r, min(page.Page-1, page.TotalPages))
+		meta["prev"] = prev
+		links = append(links, httputil.Link{URL: prev, Rel: "prev"})
+	}
+	if page.Page < page.TotalPages {
+		next := httputil.PageURL(r, page.Page+1)
+		meta["next"] = next
+		links = append(links, httputil.Link{URL: next, Rel: "next"})
+	}
+	links = append(links, httputil.Link{URL: httputil.PageURL(r, page.TotalPages), Rel: "last"})
+
+	return meta, links
+}
+
+// cursorMeta is paginationMeta for keyset pages, which only link forward.
+func cursorMeta(r *http.Request, page dto.PageInfo) (map[string]interface{}, []httputil.Link) {
+	meta := map[string]interface{}{
+		"limit":       page.Limit,
+		"next_cursor": nil,
+		"next":        nil,
+	}
+	if page.NextCursor == "" {
+		return meta, nil
+	}
+
+	next := httputil.RequestURL(r, map[string]string{"cursor": page.NextCursor})
+	meta["next_cursor"] = page.NextCursor
+	meta["next"] = next
+
+	return meta, []httputil.Link{{URL: next, Rel: "next"}}
+}
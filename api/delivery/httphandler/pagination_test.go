@@ -0,0 +1,57 @@
+package httphandler
+
+import (
+	"employee-management/domain/dto"
+	"employee-management/utils/httputil"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaginationMeta(t *testing.T) {
+	r := httptest.NewRequest("GET", "http://example.com/api/list_employee?page=2&page_size=5&position=QA", nil)
+
+	meta, links := paginationMeta(r, dto.PageInfo{Page: 2, PageSize: 5, TotalCount: 12, TotalPages: 3})
+
+	assert.Equal(t, "http://example.com/api/list_employee?page=1&page_size=5&position=QA", meta["prev"])
+	assert.Equal(t, "http://example.com/api/list_employee?page=3&page_size=5&position=QA", meta["next"])
+	assert.Equal(t, int64(12), meta["total_count"])
+	assert.Equal(t, 3, meta["total_pages"])
+	assert.Equal(t, `<http://example.com/api/list_employee?page=1&page_size=5&position=QA>; rel="first", `+
+		`<http://example.com/api/list_employee?page=1&page_size=5&position=QA>; rel="prev", `+
+		`<http://example.com/api/list_employee?page=3&page_size=5&position=QA>; rel="next", `+
+		`<http://example.com/api/list_employee?page=3&page_size=5&position=QA>; rel="last"`,
+		httputil.LinkHeader(links))
+}
+
+func TestPaginationMetaLastPage(t *testing.T) {
+	r := httptest.NewRequest("GET", "http://example.com/api/list_employee?page=3", nil)
+
+	meta, links := paginationMeta(r, dto.PageInfo{Page: 3, PageSize: 5, TotalCount: 12, TotalPages: 3})
+
+	assert.Nil(t, meta["next"])
+	assert.Equal(t, "http://example.com/api/list_employee?page=2", meta["prev"])
+	assert.Len(t, links, 3)
+}
+
+func TestPaginationMetaEmpty(t *testing.T) {
+	r := httptest.NewRequest("GET", "http://example.com/api/list_employee", nil)
+
+	meta, links := paginationMeta(r, dto.PageInfo{Page: 1, PageSize: 100})
+
+	assert.Nil(t, meta["next"])
+	assert.Nil(t, meta["prev"])
+	assert.Empty(t, links)
+}
+
+func TestPaginationMetaCursor(t *testing.T) {
+	r := httptest.NewRequest("GET", "http://example.com/api/list_employee?limit=5&cursor=abc&sort=-salary", nil)
+
+	meta, links := paginationMeta(r, dto.PageInfo{Limit: 5, NextCursor: "def"})
+
+	assert.Equal(t, 5, meta["limit"])
+	assert.Equal(t, "def", meta["next_cursor"])
+	assert.Equal(t, `<http://example.com/api/list_employee?cursor=def&limit=5&sort=-salary>; rel="next"`, httputil.LinkHeader(links))
+	assert.NotContains(t, meta, "total_count")
+}
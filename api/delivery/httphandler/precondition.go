@@ -0,0 +1,13 @@
+package httphandler
+
+import (
+	"employee-management/domain/dto"
+	"employee-management/utils/httputil"
+
+	"github.com/gin-gonic/gin"
+)
+
+// precondition reads the If-Match header of a write request.
+func precondition(ctx *gin.Context) dto.Precondition {
+	return dto.Precondition{IfMatch: httputil.ParseIfMatch(ctx.GetHeader("If-Match"))}
+}
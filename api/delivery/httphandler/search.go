@@ -0,0 +1,52 @@
+package httphandler
+
+import (
+	"employee-management/domain/dto"
+	"employee-management/utils/httputil"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SearchEmployeesHandler lists the employees best matching the q query
+// parameter, with their match scores.
+func (s *employeeHandler) SearchEmployeesHandler(ctx *gin.Context) {
+	var (
+		startTime = time.Now()
+		err       error
+	)
+	defer func() {
+		if err != nil {
+			writeError(ctx, err)
+		}
+	}()
+
+	req := new(dto.SearchEmployees)
+	if err = ctx.ShouldBindQuery(req); err != nil {
+		err = invalidRequest(err)
+		return
+	}
+
+	results, err := s.employeeUsecase.SearchEmployees(ctx.Request.Context(), req)
+	if err != nil {
+		return
+	}
+
+	data, err := json.Marshal(httputil.StandardEnvelope{
+		Data: maskSearchResults(ctx, results),
+		Status: &httputil.StandardStatus{
+			Message:   http.StatusText(http.StatusOK),
+			ErrorCode: 0,
+		},
+		Header: &httputil.StandardHeader{
+			TotalData:   len(results),
+			ProcessTime: time.Since(startTime).Seconds(),
+		},
+	})
+	if err != nil {
+		return
+	}
+	_, _ = httputil.WriteJSONResponse(ctx.Writer, data, http.StatusOK)
+}
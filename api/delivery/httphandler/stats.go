@@ -0,0 +1,57 @@
+package httphandler
+
+import (
+	"employee-management/domain/dto"
+	"employee-management/utils/httputil"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetEmployeeStatsHandler reports salary statistics of the employees
+// matching the filters of the list endpoint, optionally per group.
+func (s *employeeHandler) GetEmployeeStatsHandler(ctx *gin.Context) {
+	var (
+		startTime = time.Now()
+		err       error
+	)
+	defer func() {
+		if err != nil {
+			writeError(ctx, err)
+		}
+	}()
+
+	req := new(dto.GetEmployee)
+	if err = ctx.ShouldBindQuery(req); err != nil {
+		err = invalidRequest(err)
+		return
+	}
+	statsReq := new(dto.GetEmployeeStats)
+	if err = ctx.ShouldBindQuery(statsReq); err != nil {
+		err = invalidRequest(err)
+		return
+	}
+
+	stats, err := s.employeeUsecase.GetEmployeeStats(ctx.Request.Context(), req, statsReq)
+	if err != nil {
+		return
+	}
+
+	data, err := json.Marshal(httputil.StandardEnvelope{
+		Data: stats,
+		Status: &httputil.StandardStatus{
+			Message:   http.StatusText(http.StatusOK),
+			ErrorCode: 0,
+		},
+		Header: &httputil.StandardHeader{
+			TotalData:   len(stats.Groups),
+			ProcessTime: time.Since(startTime).Seconds(),
+		},
+	})
+	if err != nil {
+		return
+	}
+	_, _ = httputil.WriteJSONResponse(ctx.Writer, data, http.StatusOK)
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"employee-management/domain/interfaces"
+	"employee-management/utils/httputil"
+	"employee-management/utils/requestctx"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHeader carries the API key of service clients.
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyAuthentication authenticates requests carrying an X-API-Key header
+// and stores the principal of the key, granted its scopes, in the request
+// context. Requests without the header are left to the other
+// authentication middlewares; those with an invalid key are answered with
+// 401 invalid_api_key.
+func APIKeyAuthentication(keys interfaces.APIKeyUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(APIKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		principal, err := keys.AuthenticateAPIKey(c.Request.Context(), key)
+		if err != nil {
+			if httputil.WriteError(c.Writer, err) == http.StatusInternalServerError {
+				_ = c.Error(err)
+			}
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(requestctx.WithPrincipal(c.Request.Context(), principal))
+		c.Next()
+	}
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"context"
+	"employee-management/domain/apperror"
+	"employee-management/domain/dto"
+	"employee-management/domain/interfaces"
+	"employee-management/utils/requestctx"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// apiKeyUsecase accepts a single key and fails to look up emk_broken.
+type apiKeyUsecase struct {
+	interfaces.APIKeyUsecase
+}
+
+func (apiKeyUsecase) AuthenticateAPIKey(ctx context.Context, key string) (*dto.Principal, error) {
+	if key == "emk_broken" {
+		return nil, errors.New("pq: connection refused")
+	}
+	if key != "emk_valid" {
+		return nil, apperror.New(apperror.KindUnauthenticated, "invalid_api_key", "API key is unknown, revoked or expired")
+	}
+	return &dto.Principal{Subject: "api_key:1", Permissions: []string{dto.PermissionEmployeeRead}}, nil
+}
+
+func TestAPIKeyAuthentication(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		status  int
+		subject string
+	}{
+		{"valid key", "emk_valid", http.StatusNoContent, "api_key:1"},
+		{"invalid key", "emk_other", http.StatusUnauthorized, ""},
+		{"no key", "", http.StatusNoContent, ""},
+		{"lookup failure", "emk_broken", http.StatusInternalServerError, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			var subject string
+			e := gin.New()
+			e.Use(APIKeyAuthentication(apiKeyUsecase{}))
+			e.GET("/", func(c *gin.Context) {
+				if principal, ok := requestctx.Principal(c.Request.Context()); ok {
+					subject = principal.Subject
+				}
+				c.Status(http.StatusNoContent)
+			})
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.key != "" {
+				r.Header.Set(APIKeyHeader, tt.key)
+			}
+			w := httptest.NewRecorder()
+			e.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.status, w.Code)
+			assert.Equal(t, tt.subject, subject)
+			if tt.status == http.StatusUnauthorized {
+				assert.Contains(t, w.Body.String(), `"code":"invalid_api_key"`)
+			}
+			if tt.status == http.StatusInternalServerError {
+				assert.Contains(t, w.Body.String(), `"code":"internal_error"`)
+				assert.NotContains(t, w.Body.String(), "connection refused")
+			}
+		})
+	}
+}
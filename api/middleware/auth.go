@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"employee-management/utils/httputil"
+	"employee-management/utils/jwt"
+	"employee-management/utils/requestctx"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Authentication requires a bearer token accepted by verifier and stores
+// its subject, with the permissions policy grants its roles, as the
+// principal of the request. Requests without a valid token are answered
+// with 401 in the StandardEnvelope error format. Requests already
+// authenticated otherwise, e.g. with an API key, pass unchecked.
+func Authentication(verifier *jwt.Verifier, policy *Policy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := requestctx.Principal(c.Request.Context()); ok {
+			c.Next()
+			return
+		}
+
+		scheme, token, _ := strings.Cut(c.GetHeader("Authorization"), " ")
+		token = strings.TrimSpace(token)
+		if !strings.EqualFold(scheme, "Bearer") || token == "" {
+			c.Header("WWW-Authenticate", `Bearer`)
+			unauthorized(c, "missing_token", "the request must carry a bearer token in the Authorization header")
+			return
+		}
+
+		claims, err := verifier.Verify(token)
+		if err != nil {
+			c.Header("WWW-Authenticate", fmt.Sprintf(`Bearer error="invalid_token", error_description=%q`, err.Error()))
+			unauthorized(c, "invalid_token", err.Error())
+			return
+		}
+
+		c.Request = c.Request.WithContext(requestctx.WithPrincipal(c.Request.Context(), policy.principal(claims)))
+		c.Next()
+	}
+}
+
+func unauthorized(c *gin.Context, code, detail string) {
+	httputil.WriteErrorResponse(c.Writer, http.StatusUnauthorized, []httputil.StandardError{{
+		Code:   code,
+		Title:  http.StatusText(http.StatusUnauthorized),
+		Detail: detail,
+	}})
+	c.Abort()
+}
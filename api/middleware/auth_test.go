@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"employee-management/domain/dto"
+	"employee-management/utils/jwt"
+	"employee-management/utils/requestctx"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSecret = "0123456789abcdef0123456789abcdef"
+
+func hs256(claims string) string {
+	signed := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." +
+		base64.RawURLEncoding.EncodeToString([]byte(claims))
+	mac := hmac.New(sha256.New, []byte(testSecret))
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func serveAuthenticated(t *testing.T, authorization string) (*httptest.ResponseRecorder, *dto.Principal) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	path := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(path, []byte(testSecret), 0o600))
+	key, err := jwt.LoadHMACSecret(path)
+	require.NoError(t, err)
+
+	policy, err := NewPolicy("roles", map[string][]string{"viewer": {"employee:read"}})
+	require.NoError(t, err)
+
+	var principal *dto.Principal
+	e := gin.New()
+	e.Use(Authentication(jwt.NewVerifier([]*jwt.Key{key}, jwt.Options{}), policy))
+	e.GET("/", func(c *gin.Context) {
+		principal, _ = requestctx.Principal(c.Request.Context())
+		c.Status(http.StatusNoContent)
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	if authorization != "" {
+		r.Header.Set("Authorization", authorization)
+	}
+	w := httptest.NewRecorder()
+	e.ServeHTTP(w, r)
+	return w, principal
+}
+
+func TestAuthentication(t *testing.T) {
+	token := hs256(`{"sub":"alice","roles":["viewer","auditor"],"exp":` + strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10) + `}`)
+
+	w, principal := serveAuthenticated(t, "Bearer "+token)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, &dto.Principal{
+		Subject:     "alice",
+		Roles:       []string{"viewer", "auditor"},
+		Permissions: []string{"employee:read"},
+	}, principal)
+}
+
+func TestAuthenticationRejects(t *testing.T) {
+	expired := hs256(`{"sub":"alice","exp":` + strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10) + `}`)
+
+	tests := []struct {
+		name          string
+		authorization string
+		code          string
+		challenge     string
+	}{
+		{"missing header", "", "missing_token", `Bearer`},
+		{"other scheme", "Basic YWxpY2U6c2VjcmV0", "missing_token", `Bearer`},
+		{"expired token", "Bearer " + expired, "invalid_token", `Bearer error="invalid_token", error_description="token is expired"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, principal := serveAuthenticated(t, tt.authorization)
+
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+			assert.Nil(t, principal)
+			assert.Equal(t, tt.challenge, w.Header().Get("WWW-Authenticate"))
+			assert.Contains(t, w.Body.String(), `"code":"`+tt.code+`"`)
+		})
+	}
+}
+
+func TestNewPolicyRejectsUnknownPermissions(t *testing.T) {
+	_, err := NewPolicy("roles", map[string][]string{"viewer": {"employee:read", "salary:peek"}})
+
+	assert.ErrorContains(t, err, `unknown permission "salary:peek"`)
+}
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"employee-management/domain/dto"
+	"employee-management/utils/jwt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Policy maps the roles carried by bearer tokens onto permissions.
+type Policy struct {
+	claim string
+	roles map[string][]string
+}
+
+// NewPolicy returns a Policy reading the roles of a token from claim and
+// granting each role its permissions in roles. Every permission must be
+// one of dto.Permissions.
+func NewPolicy(claim string, roles map[string][]string) (*Policy, error) {
+	for role, permissions := range roles {
+		for _, permission := range permissions {
+			if !slices.Contains(dto.Permissions, permission) {
+				return nil, errors.Errorf("role %s is granted the unknown permission %q", role, permission)
+			}
+		}
+	}
+	return &Policy{claim: claim, roles: roles}, nil
+}
+
+// principal returns the caller identified by claims. Roles the policy does
+// not know grant nothing.
+func (p *Policy) principal(claims *jwt.Claims) *dto.Principal {
+	principal := &dto.Principal{Subject: claims.Subject}
+	switch value := claims.Raw[p.claim].(type) {
+	case string:
+		principal.Roles = strings.Fields(value)
+	case []interface{}:
+		for _, role := range value {
+			if role, ok := role.(string); ok {
+				principal.Roles = append(principal.Roles, role)
+			}
+		}
+	}
+
+	granted := make(map[string]bool)
+	for _, role := range principal.Roles {
+		for _, permission := range p.roles[role] {
+			granted[permission] = true
+		}
+	}
+	for permission := range granted {
+		principal.Permissions = append(principal.Permissions, permission)
+	}
+	sort.Strings(principal.Permissions)
+	return principal
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ForwardedProtoHeader carries the scheme a proxy received the request with.
+const ForwardedProtoHeader = "X-Forwarded-Proto"
+
+// ForwardedProto drops the X-Forwarded-Proto header of requests that do
+// not come from one of the trusted proxies, given as addresses or CIDR
+// ranges like gin's trusted proxies, so that links built from the request
+// cannot be pointed at another scheme by the client.
+func ForwardedProto(trustedProxies []string) (gin.HandlerFunc, error) {
+	networks := make([]*net.IPNet, 0, len(trustedProxies))
+	for _, proxy := range trustedProxies {
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q", proxy)
+			}
+			bits := 32
+			if ip.To4() == nil {
+				bits = 128
+			}
+			proxy = fmt.Sprintf("%s/%d", proxy, bits)
+		}
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+		}
+		networks = append(networks, network)
+	}
+
+	return func(c *gin.Context) {
+		if !trusted(networks, net.ParseIP(c.RemoteIP())) {
+			c.Request.Header.Del(ForwardedProtoHeader)
+		}
+		c.Next()
+	}, nil
+}
+
+func trusted(networks []*net.IPNet, ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForwardedProto(t *testing.T) {
+	tests := []struct {
+		name       string
+		remoteAddr string
+		proto      string
+	}{
+		{"trusted proxy", "10.0.0.7:41000", "https"},
+		{"trusted address", "192.168.1.1:41000", "https"},
+		{"client", "203.0.113.9:41000", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			forwardedProto, err := ForwardedProto([]string{"10.0.0.0/8", "192.168.1.1"})
+			require.NoError(t, err)
+			var proto string
+			e := gin.New()
+			e.Use(forwardedProto)
+			e.GET("/", func(c *gin.Context) {
+				proto = c.GetHeader(ForwardedProtoHeader)
+				c.Status(http.StatusNoContent)
+			})
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = tt.remoteAddr
+			r.Header.Set(ForwardedProtoHeader, "https")
+			e.ServeHTTP(httptest.NewRecorder(), r)
+
+			assert.Equal(t, tt.proto, proto)
+		})
+	}
+
+	_, err := ForwardedProto([]string{"not-an-address"})
+	assert.Error(t, err)
+}
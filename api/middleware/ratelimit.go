@@ -0,0 +1,154 @@
+package middleware
+
+import (
+	"context"
+	"employee-management/domain/dto"
+	"employee-management/domain/interfaces"
+	"employee-management/utils/httputil"
+	"employee-management/utils/requestctx"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimitOptions are the limits applied by RateLimit.
+type RateLimitOptions struct {
+	// Default limits every route without an override. The routes share
+	// one bucket per client.
+	Default dto.RateLimit
+	// Routes overrides Default for routes named "METHOD /path", with the
+	// path as registered, e.g. "GET /api/employee/:employee_id". Each of
+	// them has buckets of its own.
+	Routes map[string]dto.RateLimit
+}
+
+// RateLimit limits the requests of every client with token buckets kept
+// in store. Clients are identified by their principal, or by their IP
+// address when they are not authenticated, so it must run after the
+// authentication middlewares. Responses carry the RateLimit-Limit,
+// RateLimit-Remaining and RateLimit-Reset headers; requests over the limit
+// are answered with 429 and Retry-After. When the store fails, requests
+// are let through rather than failing the API.
+func RateLimit(store interfaces.RateLimitStore, opts RateLimitOptions) gin.HandlerFunc {
+	return rateLimit(store, func(c *gin.Context) (string, dto.RateLimit) {
+		route, limit := "*", opts.Default
+		if override, ok := opts.Routes[c.Request.Method+" "+c.FullPath()]; ok {
+			route, limit = c.Request.Method+" "+c.FullPath(), override
+		}
+
+		identity := "ip:" + c.ClientIP()
+		if principal, ok := requestctx.Principal(c.Request.Context()); ok {
+			identity = "sub:" + principal.Subject
+		}
+		return route + "|" + identity, limit
+	})
+}
+
+// AddressRateLimit limits the requests of every client IP address like
+// RateLimit, whether or not the client authenticates. It runs before the
+// authentication middlewares, so that the attempts they reject count too:
+// otherwise invalid credentials could be tried, and looked up, without
+// limit.
+func AddressRateLimit(store interfaces.RateLimitStore, limit dto.RateLimit) gin.HandlerFunc {
+	return rateLimit(store, func(c *gin.Context) (string, dto.RateLimit) {
+		return "address|ip:" + c.ClientIP(), limit
+	})
+}
+
+// rateLimit takes a token from the bucket bucket picks for the request.
+func rateLimit(store interfaces.RateLimitStore, bucket func(c *gin.Context) (string, dto.RateLimit)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key, limit := bucket(c)
+		result, err := store.Take(c.Request.Context(), key, limit)
+		if err != nil {
+			_ = c.Error(err)
+			c.Next()
+			return
+		}
+
+		header := c.Writer.Header()
+		header.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+		header.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		header.Set("RateLimit-Reset", seconds(result.Reset))
+		if !result.Allowed {
+			header.Set("Retry-After", seconds(result.RetryAfter))
+			httputil.WriteErrorResponse(c.Writer, http.StatusTooManyRequests, []httputil.StandardError{{
+				Code:   "rate_limited",
+				Title:  http.StatusText(http.StatusTooManyRequests),
+				Detail: fmt.Sprintf("rate limit exceeded, retry in %s seconds", seconds(result.RetryAfter)),
+			}})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// seconds rounds d up to whole seconds.
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
+
+// memorySweepTakes is the number of takes between two sweeps of the
+// buckets that are full again.
+const memorySweepTakes = 1024
+
+type memoryRateLimitStore struct {
+	mu      sync.Mutex
+	buckets map[string]*memoryBucket
+	takes   int
+	now     func() time.Time
+}
+
+type memoryBucket struct {
+	tokens    float64
+	updatedAt time.Time
+	// fullAt is when the bucket is full again and may be forgotten.
+	fullAt time.Time
+}
+
+// NewMemoryRateLimitStore returns a RateLimitStore keeping the buckets in
+// memory, which limits every instance of the server on its own.
+func NewMemoryRateLimitStore() interfaces.RateLimitStore {
+	return &memoryRateLimitStore{
+		buckets: make(map[string]*memoryBucket),
+		now:     time.Now,
+	}
+}
+
+func (s *memoryRateLimitStore) Take(ctx context.Context, key string, limit dto.RateLimit) (dto.RateLimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.takes++
+	if s.takes%memorySweepTakes == 0 {
+		for k, b := range s.buckets {
+			if !now.Before(b.fullAt) {
+				delete(s.buckets, k)
+			}
+		}
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &memoryBucket{tokens: limit.Capacity(), updatedAt: now}
+		s.buckets[key] = b
+	}
+	elapsed := math.Max(0, now.Sub(b.updatedAt).Seconds())
+	b.tokens = math.Min(limit.Capacity(), b.tokens+elapsed*limit.Rate())
+	b.updatedAt = now
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+	result := limit.Result(b.tokens, allowed)
+	b.fullAt = now.Add(result.Reset)
+	return result, nil
+}
@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"context"
+	"employee-management/domain/dto"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// failingRateLimitStore stands in for a store whose database is down.
+type failingRateLimitStore struct{}
+
+func (failingRateLimitStore) Take(ctx context.Context, key string, limit dto.RateLimit) (dto.RateLimitResult, error) {
+	return dto.RateLimitResult{}, errors.New("connection refused")
+}
+
+func newRateLimitedEngine(store *memoryRateLimitStore) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	e := gin.New()
+	e.Use(RateLimit(store, RateLimitOptions{
+		Default: dto.RateLimit{Requests: 60, Period: time.Minute, Burst: 2},
+		Routes: map[string]dto.RateLimit{
+			"GET /api/list_employee": {Requests: 1, Period: time.Minute},
+		},
+	}))
+	ok := func(c *gin.Context) { c.Status(http.StatusNoContent) }
+	e.GET("/api/list_employee", ok)
+	e.GET("/api/employee/:employee_id", ok)
+	e.GET("/api/departments", ok)
+	return e
+}
+
+func get(e *gin.Engine, target, remoteAddr string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodGet, target, nil)
+	r.RemoteAddr = remoteAddr
+	w := httptest.NewRecorder()
+	e.ServeHTTP(w, r)
+	return w
+}
+
+func TestRateLimit(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	store := NewMemoryRateLimitStore().(*memoryRateLimitStore)
+	store.now = func() time.Time { return now }
+	e := newRateLimitedEngine(store)
+
+	w := get(e, "/api/employee/1", "10.0.0.1:1234")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "2", w.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", w.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "1", w.Header().Get("RateLimit-Reset"))
+
+	// routes without an override share the default bucket
+	assert.Equal(t, http.StatusNoContent, get(e, "/api/departments", "10.0.0.1:1234").Code)
+	w = get(e, "/api/employee/2", "10.0.0.1:1234")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+	assert.Contains(t, w.Body.String(), `"code":"rate_limited"`)
+
+	// other clients and overridden routes have buckets of their own
+	assert.Equal(t, http.StatusNoContent, get(e, "/api/employee/1", "10.0.0.2:1234").Code)
+	assert.Equal(t, http.StatusNoContent, get(e, "/api/list_employee", "10.0.0.1:1234").Code)
+	w = get(e, "/api/list_employee", "10.0.0.1:1234")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "60", w.Header().Get("Retry-After"))
+
+	// buckets refill over time
+	now = now.Add(time.Second)
+	assert.Equal(t, http.StatusNoContent, get(e, "/api/employee/2", "10.0.0.1:1234").Code)
+}
+
+func TestRateLimitLetsRequestsThroughWhenTheStoreFails(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	e := gin.New()
+	e.Use(RateLimit(failingRateLimitStore{}, RateLimitOptions{Default: dto.RateLimit{Requests: 1, Period: time.Minute}}))
+	e.GET("/", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	w := get(e, "/", "10.0.0.1:1234")
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+}
+
+func TestAddressRateLimitCountsFailedAuthentications(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := NewMemoryRateLimitStore()
+	e := gin.New()
+	e.Use(AddressRateLimit(store, dto.RateLimit{Requests: 2, Period: time.Minute}))
+	e.Use(APIKeyAuthentication(apiKeyUsecase{}))
+	e.GET("/", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	attempt := func(remoteAddr string) int {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.RemoteAddr = remoteAddr
+		r.Header.Set(APIKeyHeader, "emk_guess")
+		w := httptest.NewRecorder()
+		e.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, attempt("10.0.0.1:1234"))
+	assert.Equal(t, http.StatusUnauthorized, attempt("10.0.0.1:1234"))
+	assert.Equal(t, http.StatusTooManyRequests, attempt("10.0.0.1:1234"))
+	assert.Equal(t, http.StatusUnauthorized, attempt("10.0.0.2:1234"))
+}
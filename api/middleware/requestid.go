@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"employee-management/utils/requestctx"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDHeader is the header used to propagate the request ID.
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID reuses the caller's X-Request-ID or generates a new one, echoes
+// it on the response and stores it in the request context.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" || len(requestID) > 128 {
+			requestID = newRequestID()
+		}
+
+		c.Writer.Header().Set(RequestIDHeader, requestID)
+		c.Request = c.Request.WithContext(requestctx.WithRequestID(c.Request.Context(), requestID))
+		c.Next()
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -7,10 +7,10 @@ import (
 	"github.com/go-openapi/runtime/middleware"
 )
 
-func Middleware() http.Handler {
+func Middleware(specURL string) http.Handler {
 	var r middleware.RedocOpts
 	// Override default path to your swagger.json/swagger.yaml file
-	r.SpecURL = "/docs/swagger.yaml"
+	r.SpecURL = specURL
 	return middleware.Redoc(r, serverStatic())
 }
 
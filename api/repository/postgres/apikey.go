@@ -0,0 +1,145 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"employee-management/domain/dto"
+	"employee-management/domain/interfaces"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+const entityAPIKey = "api_key"
+
+// Like employee_audit, the api_keys table is accessed with plain SQL.
+const (
+	apiKeyColumns      = `"id","name","prefix","key_hash","scopes","expires_at","last_used_at","created_at","revoked_at"`
+	insertAPIKey       = `INSERT INTO "api_keys" ("name","prefix","key_hash","scopes","expires_at","created_at") VALUES ($1,$2,$3,$4,$5,$6) RETURNING "id"`
+	selectAPIKeys      = `SELECT ` + apiKeyColumns + ` FROM "api_keys" ORDER BY "id" DESC LIMIT $1 OFFSET $2`
+	countAPIKeys       = `SELECT COUNT(*) FROM "api_keys"`
+	selectAPIKeyByHash = `SELECT ` + apiKeyColumns + ` FROM "api_keys" WHERE "key_hash"=$1`
+	revokeAPIKey       = `UPDATE "api_keys" SET "revoked_at"=COALESCE("revoked_at",$1) WHERE "id"=$2`
+	touchAPIKey        = `UPDATE "api_keys" SET "last_used_at"=$1 WHERE "id"=$2 AND ("last_used_at" IS NULL OR "last_used_at" < $3)`
+)
+
+// apiKeyTouchStep is the precision of last_used_at.
+const apiKeyTouchStep = time.Minute
+
+type apiKeyRepository struct {
+	db *sql.DB
+}
+
+// NewAPIKeyRepository returns an APIKeyRepository.
+func NewAPIKeyRepository(db *sql.DB) interfaces.APIKeyRepository {
+	return &apiKeyRepository{
+		db: db,
+	}
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, key *dto.APIKey) error {
+	key.CreatedAt = now()
+	if key.ExpiresAt != nil {
+		// expires_at has no time zone: store the instant in the location
+		// of the other timestamps rather than the offset of the client
+		expiresAt := key.ExpiresAt.In(boil.GetLocation())
+		key.ExpiresAt = &expiresAt
+	}
+	err := executor(ctx, r.db).QueryRowContext(ctx, insertAPIKey,
+		key.Name,
+		key.Prefix,
+		key.Hash,
+		pq.StringArray(key.Scopes),
+		null.TimeFromPtr(key.ExpiresAt),
+		key.CreatedAt,
+	).Scan(&key.ID)
+
+	return translateError(err, entityAPIKey)
+}
+
+func (r *apiKeyRepository) List(ctx context.Context, limit, offset int) ([]*dto.APIKey, error) {
+	rows, err := executor(ctx, r.db).QueryContext(ctx, selectAPIKeys, limit, offset)
+	if err != nil {
+		return nil, translateError(err, entityAPIKey)
+	}
+	defer rows.Close()
+
+	keys := make([]*dto.APIKey, 0, limit)
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, translateError(err, entityAPIKey)
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, translateError(err, entityAPIKey)
+	}
+
+	return keys, nil
+}
+
+func (r *apiKeyRepository) Count(ctx context.Context) (int64, error) {
+	var count int64
+	err := executor(ctx, r.db).QueryRowContext(ctx, countAPIKeys).Scan(&count)
+	if err != nil {
+		return 0, translateError(err, entityAPIKey)
+	}
+
+	return count, nil
+}
+
+func (r *apiKeyRepository) FindByHash(ctx context.Context, hash string) (*dto.APIKey, error) {
+	key, err := scanAPIKey(executor(ctx, r.db).QueryRowContext(ctx, selectAPIKeyByHash, hash))
+	if err != nil {
+		return nil, translateError(err, entityAPIKey)
+	}
+	return key, nil
+}
+
+func (r *apiKeyRepository) Revoke(ctx context.Context, apiKeyID int64) error {
+	result, err := executor(ctx, r.db).ExecContext(ctx, revokeAPIKey, now(), apiKeyID)
+	if err != nil {
+		return translateError(err, entityAPIKey)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return translateError(err, entityAPIKey)
+	}
+	if rows == 0 {
+		return translateError(sql.ErrNoRows, entityAPIKey)
+	}
+
+	return nil
+}
+
+// Touch only writes when last_used_at is more than apiKeyTouchStep old, so
+// that a busy client does not update the row on every request.
+func (r *apiKeyRepository) Touch(ctx context.Context, apiKeyID int64, at time.Time) error {
+	// like expires_at, last_used_at is stored in the location of the
+	// other timestamps
+	at = at.In(boil.GetLocation())
+	_, err := executor(ctx, r.db).ExecContext(ctx, touchAPIKey, at, apiKeyID, at.Add(-apiKeyTouchStep))
+	return translateError(err, entityAPIKey)
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAPIKey(row rowScanner) (*dto.APIKey, error) {
+	var (
+		key                              dto.APIKey
+		scopes                           pq.StringArray
+		expiresAt, lastUsedAt, revokedAt null.Time
+	)
+	err := row.Scan(&key.ID, &key.Name, &key.Prefix, &key.Hash, &scopes, &expiresAt, &lastUsedAt, &key.CreatedAt, &revokedAt)
+	if err != nil {
+		return nil, err
+	}
+	key.Scopes = scopes
+	key.ExpiresAt, key.LastUsedAt, key.RevokedAt = expiresAt.Ptr(), lastUsedAt.Ptr(), revokedAt.Ptr()
+	return &key, nil
+}
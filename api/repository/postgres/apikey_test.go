@@ -0,0 +1,147 @@
+package postgres
+
+import (
+	"context"
+	"employee-management/domain/apperror"
+	"employee-management/domain/dto"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyCreate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(insertAPIKey)).
+		WithArgs("payroll", "emk_abcdefgh", "hash", pq.StringArray{"employee:read"}, nil, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+
+	repo := NewAPIKeyRepository(db)
+	key := &dto.APIKey{Name: "payroll", Prefix: "emk_abcdefgh", Hash: "hash", Scopes: []string{"employee:read"}}
+
+	err = repo.Create(context.Background(), key)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), key.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPIKeyCreateStoresExpiryInUTC(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	expiresAt := time.Date(2026, 12, 1, 0, 0, 0, 0, time.FixedZone("+05:00", 5*60*60))
+	mock.ExpectQuery(regexp.QuoteMeta(insertAPIKey)).
+		WithArgs("payroll", "emk_abcdefgh", "hash", pq.StringArray{"employee:read"}, time.Date(2026, 11, 30, 19, 0, 0, 0, time.UTC), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+
+	repo := NewAPIKeyRepository(db)
+	key := &dto.APIKey{Name: "payroll", Prefix: "emk_abcdefgh", Hash: "hash", Scopes: []string{"employee:read"}, ExpiresAt: &expiresAt}
+
+	err = repo.Create(context.Background(), key)
+
+	assert.NoError(t, err)
+	assert.True(t, key.ExpiresAt.Equal(expiresAt))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPIKeyList(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectAPIKeys)).WithArgs(2, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "prefix", "key_hash", "scopes", "expires_at", "last_used_at", "created_at", "revoked_at"}).
+			AddRow(1, "payroll", "emk_abcdefgh", "hash", "{employee:read}", nil, nil, time.Now(), nil))
+	mock.ExpectQuery(regexp.QuoteMeta(countAPIKeys)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	repo := NewAPIKeyRepository(db)
+
+	keys, err := repo.List(context.Background(), 2, 2)
+	assert.NoError(t, err)
+	assert.Len(t, keys, 1)
+	assert.Equal(t, "payroll", keys[0].Name)
+
+	count, err := repo.Count(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPIKeyFindByHash(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	expiresAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta(selectAPIKeyByHash)).WithArgs("hash").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "prefix", "key_hash", "scopes", "expires_at", "last_used_at", "created_at", "revoked_at"}).
+			AddRow(3, "payroll", "emk_abcdefgh", "hash", "{employee:read,salary:read}", expiresAt, nil, time.Now(), nil))
+	mock.ExpectQuery(regexp.QuoteMeta(selectAPIKeyByHash)).WithArgs("other").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	repo := NewAPIKeyRepository(db)
+
+	key, err := repo.FindByHash(context.Background(), "hash")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"employee:read", "salary:read"}, key.Scopes)
+	assert.Equal(t, expiresAt, *key.ExpiresAt)
+	assert.Nil(t, key.LastUsedAt)
+
+	_, err = repo.FindByHash(context.Background(), "other")
+	assert.Equal(t, apperror.KindNotFound, apperror.KindOf(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPIKeyTouch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	at := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	mock.ExpectExec(regexp.QuoteMeta(touchAPIKey)).
+		WithArgs(at, int64(3), at.Add(-time.Minute)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := NewAPIKeyRepository(db)
+
+	assert.NoError(t, repo.Touch(context.Background(), 3, at))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPIKeyTouchStoresTimeInUTC(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	at := time.Date(2025, 1, 1, 12, 0, 0, 0, time.FixedZone("+05:00", 5*60*60))
+	utc := time.Date(2025, 1, 1, 7, 0, 0, 0, time.UTC)
+	mock.ExpectExec(regexp.QuoteMeta(touchAPIKey)).
+		WithArgs(utc, int64(3), utc.Add(-time.Minute)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := NewAPIKeyRepository(db)
+
+	assert.NoError(t, repo.Touch(context.Background(), 3, at))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
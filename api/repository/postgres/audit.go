@@ -0,0 +1,209 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"employee-management/api/repository/sqlboiler"
+	"employee-management/domain/dto"
+	"employee-management/domain/interfaces"
+	"employee-management/utils/requestctx"
+	"encoding/json"
+
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+const (
+	entityEmployeeAudit = "employee_audit"
+
+	// anonymousActor is recorded when the change has no principal.
+	anonymousActor = "anonymous"
+	// systemActor is the actor of the salary changes that took effect on
+	// a scheduled day rather than through a request.
+	systemActor = "system"
+)
+
+// The employee_audit table is accessed with plain SQL: it is append only
+// and never needs the generated model helpers.
+const (
+	insertEmployeeAudit = `INSERT INTO "employee_audit" ("employee_id","operation","actor","request_id","changes","created_at") VALUES ($1,$2,$3,$4,$5,$6)`
+	selectEmployeeAudit = `SELECT "id","employee_id","operation","actor","request_id","changes","created_at" FROM (` + employeeHistory + `) AS "history" ORDER BY "created_at" DESC, "id" DESC LIMIT $2 OFFSET $3`
+	countEmployeeAudit  = `SELECT COUNT(*) FROM (` + employeeHistory + `) AS "history"`
+	// keepScheduledSalaryChanges copies the scheduled salary changes into
+	// the log before a purge removes the compensation records.
+	keepScheduledSalaryChanges = `INSERT INTO "employee_audit" ("employee_id","operation","actor","request_id","changes","created_at") ` +
+		`SELECT "employee_id","operation","actor","request_id","changes","created_at" FROM (` + scheduledSalaryChanges + `) AS "scheduled"`
+)
+
+// scheduledSalaryChanges derives an update entry for every compensation
+// record that was scheduled ahead and has taken effect since: such changes
+// do not write the employee row, so the hooks never record them. Changes
+// effective on the day they are made update the row and are logged by the
+// hooks instead. The entries have no id of their own.
+const scheduledSalaryChanges = `SELECT 0 AS "id","c"."employee_id",'` + dto.AuditUpdate + `' AS "operation",'` + systemActor + `' AS "actor",NULL AS "request_id",` +
+	`jsonb_build_object('salary', jsonb_build_object('from', "p"."amount", 'to', "c"."amount")) AS "changes","c"."effective_from"::timestamp AS "created_at" ` +
+	`FROM "compensation" AS "c" LEFT JOIN "compensation" AS "p" ON "p"."employee_id" = "c"."employee_id" AND "p"."effective_to" = "c"."effective_from" ` +
+	`WHERE "c"."employee_id"=$1 AND "c"."effective_from" > "c"."created_at"::date AND "c"."effective_from" <= (NOW() AT TIME ZONE 'UTC')::date`
+
+// employeeHistory is the log of an employee together with its scheduled
+// salary changes.
+const employeeHistory = `SELECT "id","employee_id","operation","actor","request_id","changes","created_at" FROM "employee_audit" WHERE "employee_id"=$1 ` +
+	`UNION ALL ` + scheduledSalaryChanges
+
+// RegisterEmployeeAuditHooks makes every Insert, Update and Delete of an
+// employee model write an employee_audit row through the same executor, so
+// that the entry commits or rolls back together with the change. Bulk
+// UpdateAll and DeleteAll calls bypass the hooks and must not be used for
+// employees.
+func RegisterEmployeeAuditHooks() {
+	sqlboiler.AddEmployeeHook(boil.AfterInsertHook, auditEmployeeInsert)
+	sqlboiler.AddEmployeeHook(boil.BeforeUpdateHook, auditEmployeeUpdate)
+	sqlboiler.AddEmployeeHook(boil.BeforeDeleteHook, auditEmployeeDelete)
+}
+
+func auditEmployeeInsert(ctx context.Context, exec boil.ContextExecutor, o *sqlboiler.Employee) error {
+	return writeEmployeeAudit(ctx, exec, o.ID, dto.AuditCreate, diffEmployee(nil, o))
+}
+
+// auditEmployeeUpdate runs before the update, while the stored row still
+// holds the old values.
+func auditEmployeeUpdate(ctx context.Context, exec boil.ContextExecutor, o *sqlboiler.Employee) error {
+	old, err := sqlboiler.FindEmployee(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	operation := dto.AuditUpdate
+	switch {
+	case !old.DeletedAt.Valid && o.DeletedAt.Valid:
+		operation = dto.AuditDelete
+	case old.DeletedAt.Valid && !o.DeletedAt.Valid:
+		operation = dto.AuditRestore
+	}
+
+	changes := diffEmployee(old, o)
+	if len(changes) == 0 {
+		return nil
+	}
+	return writeEmployeeAudit(ctx, exec, o.ID, operation, changes)
+}
+
+func auditEmployeeDelete(ctx context.Context, exec boil.ContextExecutor, o *sqlboiler.Employee) error {
+	if _, err := exec.ExecContext(ctx, keepScheduledSalaryChanges, o.ID); err != nil {
+		return translateError(err, entityEmployeeAudit)
+	}
+	return writeEmployeeAudit(ctx, exec, o.ID, dto.AuditPurge, diffEmployee(o, nil))
+}
+
+// diffEmployee returns the audited fields that differ between before and
+// after; either may be nil.
+func diffEmployee(before, after *sqlboiler.Employee) map[string]dto.FieldChange {
+	values := func(e *sqlboiler.Employee) map[string]interface{} {
+		if e == nil {
+			return map[string]interface{}{}
+		}
+		return map[string]interface{}{
+			sqlboiler.EmployeeColumns.Name:         e.Name,
+			sqlboiler.EmployeeColumns.Position:     e.Position,
+			sqlboiler.EmployeeColumns.Salary:       e.Salary,
+			sqlboiler.EmployeeColumns.DeletedAt:    e.DeletedAt.Ptr(),
+			sqlboiler.EmployeeColumns.DepartmentID: e.DepartmentID.Ptr(),
+			sqlboiler.EmployeeColumns.ManagerID:    e.ManagerID.Ptr(),
+		}
+	}
+	from, to := values(before), values(after)
+
+	changes := make(map[string]dto.FieldChange)
+	for _, column := range []string{
+		sqlboiler.EmployeeColumns.Name,
+		sqlboiler.EmployeeColumns.Position,
+		sqlboiler.EmployeeColumns.Salary,
+		sqlboiler.EmployeeColumns.DepartmentID,
+		sqlboiler.EmployeeColumns.ManagerID,
+		sqlboiler.EmployeeColumns.DeletedAt,
+	} {
+		change := dto.FieldChange{From: from[column], To: to[column]}
+		if !sameValue(change.From, change.To) {
+			changes[column] = change
+		}
+	}
+	return changes
+}
+
+// sameValue compares audited values by their JSON form, which also treats
+// a nil time and a missing value alike.
+func sameValue(a, b interface{}) bool {
+	x, _ := json.Marshal(a)
+	y, _ := json.Marshal(b)
+	return string(x) == string(y)
+}
+
+func writeEmployeeAudit(ctx context.Context, exec boil.ContextExecutor, employeeID int, operation string, changes map[string]dto.FieldChange) error {
+	actor := anonymousActor
+	if principal, ok := requestctx.Principal(ctx); ok {
+		actor = principal.Subject
+	}
+	requestID := requestctx.RequestID(ctx)
+
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+
+	_, err = exec.ExecContext(ctx, insertEmployeeAudit,
+		employeeID, operation, actor, null.NewString(requestID, requestID != ""), data, now())
+	return translateError(err, entityEmployeeAudit)
+}
+
+type employeeAuditRepository struct {
+	db *sql.DB
+}
+
+// NewEmployeeAuditRepository returns an EmployeeAuditRepository reading the
+// rows written by the audit hooks.
+func NewEmployeeAuditRepository(db *sql.DB) interfaces.EmployeeAuditRepository {
+	return &employeeAuditRepository{
+		db: db,
+	}
+}
+
+func (r *employeeAuditRepository) List(ctx context.Context, employeeID int, limit, offset int) ([]*dto.EmployeeAudit, error) {
+	rows, err := executor(ctx, r.db).QueryContext(ctx, selectEmployeeAudit, employeeID, limit, offset)
+	if err != nil {
+		return nil, translateError(err, entityEmployeeAudit)
+	}
+	defer rows.Close()
+
+	audits := make([]*dto.EmployeeAudit, 0, limit)
+	for rows.Next() {
+		var (
+			audit     dto.EmployeeAudit
+			requestID null.String
+			changes   []byte
+		)
+		err := rows.Scan(&audit.ID, &audit.EmployeeID, &audit.Operation, &audit.Actor, &requestID, &changes, &audit.CreatedAt)
+		if err != nil {
+			return nil, translateError(err, entityEmployeeAudit)
+		}
+		if err := json.Unmarshal(changes, &audit.Changes); err != nil {
+			return nil, err
+		}
+		audit.RequestID = requestID.String
+		audits = append(audits, &audit)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, translateError(err, entityEmployeeAudit)
+	}
+
+	return audits, nil
+}
+
+func (r *employeeAuditRepository) Count(ctx context.Context, employeeID int) (int64, error) {
+	var count int64
+	err := executor(ctx, r.db).QueryRowContext(ctx, countEmployeeAudit, employeeID).Scan(&count)
+	if err != nil {
+		return 0, translateError(err, entityEmployeeAudit)
+	}
+
+	return count, nil
+}
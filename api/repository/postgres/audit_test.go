@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"employee-management/api/repository/sqlboiler"
+	"employee-management/domain/dto"
+	"employee-management/utils/requestctx"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/volatiletech/null/v8"
+)
+
+func TestAuditEmployeeUpdate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`select * from "employee" where "id"=$1`)).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(employeeColumns).AddRow(1, "John Doe", "Developer", 60000, time.Now(), time.Now(), nil, nil, nil))
+	mock.ExpectExec(regexp.QuoteMeta(insertEmployeeAudit)).
+		WithArgs(1, dto.AuditUpdate, "hr-user", "req-1", []byte(`{"position":{"from":"Developer","to":"Lead Engineer"}}`), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	ctx := requestctx.WithPrincipal(requestctx.WithRequestID(context.Background(), "req-1"), &dto.Principal{Subject: "hr-user"})
+	err = auditEmployeeUpdate(ctx, db, &sqlboiler.Employee{ID: 1, Name: "John Doe", Position: "Lead Engineer", Salary: 60000})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuditEmployeeSoftDelete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	deletedAt := time.Date(2024, 6, 16, 11, 36, 17, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta(`select * from "employee" where "id"=$1`)).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(employeeColumns).AddRow(1, "John Doe", "Developer", 60000, time.Now(), time.Now(), nil, nil, nil))
+	mock.ExpectExec(regexp.QuoteMeta(insertEmployeeAudit)).
+		WithArgs(1, dto.AuditDelete, anonymousActor, nil, []byte(`{"deleted_at":{"from":null,"to":"2024-06-16T11:36:17Z"}}`), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err = auditEmployeeUpdate(context.Background(), db, &sqlboiler.Employee{
+		ID: 1, Name: "John Doe", Position: "Developer", Salary: 60000, DeletedAt: null.TimeFrom(deletedAt),
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuditEmployeePurgeKeepsScheduledSalaryChanges(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(keepScheduledSalaryChanges)).WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(insertEmployeeAudit)).
+		WithArgs(1, dto.AuditPurge, anonymousActor, nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err = auditEmployeeDelete(context.Background(), db, &sqlboiler.Employee{ID: 1, Name: "John Doe", Position: "Developer", Salary: 60000})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEmployeeAuditListIncludesScheduledSalaryChanges(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	effectiveFrom := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM "compensation" AS "c" LEFT JOIN "compensation" AS "p"`)).WithArgs(1, 10, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "employee_id", "operation", "actor", "request_id", "changes", "created_at"}).
+			AddRow(0, 1, dto.AuditUpdate, systemActor, nil, []byte(`{"salary":{"from":60000,"to":65000}}`), effectiveFrom).
+			AddRow(7, 1, dto.AuditCreate, "hr-user", nil, []byte(`{"salary":{"from":null,"to":60000}}`), effectiveFrom.AddDate(0, -1, 0)))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM (SELECT "id","employee_id","operation","actor","request_id","changes","created_at" FROM "employee_audit" WHERE "employee_id"=$1 UNION ALL`)).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	repo := NewEmployeeAuditRepository(db)
+
+	audits, err := repo.List(context.Background(), 1, 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, audits, 2)
+	assert.Zero(t, audits[0].ID)
+	assert.Equal(t, systemActor, audits[0].Actor)
+	assert.Equal(t, dto.FieldChange{From: 60000.0, To: 65000.0}, audits[0].Changes["salary"])
+	assert.Equal(t, effectiveFrom, audits[0].CreatedAt)
+
+	count, err := repo.Count(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEmployeeAuditList(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectEmployeeAudit)).WithArgs(1, 10, 20).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "employee_id", "operation", "actor", "request_id", "changes", "created_at"}).
+			AddRow(7, 1, dto.AuditCreate, "hr-user", nil, []byte(`{"name":{"from":null,"to":"John Doe"}}`), time.Now()))
+
+	repo := NewEmployeeAuditRepository(db)
+
+	audits, err := repo.List(context.Background(), 1, 10, 20)
+
+	assert.NoError(t, err)
+	assert.Len(t, audits, 1)
+	assert.Equal(t, "hr-user", audits[0].Actor)
+	assert.Empty(t, audits[0].RequestID)
+	assert.Equal(t, dto.FieldChange{To: "John Doe"}, audits[0].Changes["name"])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
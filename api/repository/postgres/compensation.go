@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"employee-management/domain/dto"
+	"employee-management/domain/interfaces"
+
+	"github.com/volatiletech/null/v8"
+)
+
+const entityCompensation = "compensation"
+
+// Like employee_audit, the compensation table is accessed with plain SQL.
+const (
+	selectCompensation = `SELECT "id","employee_id","amount","currency","reason","effective_from","effective_to","created_at" FROM "compensation" WHERE "employee_id"=$1 ORDER BY "effective_from"`
+	insertCompensation = `INSERT INTO "compensation" ("employee_id","amount","currency","reason","effective_from","effective_to","created_at") VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING "id"`
+	updateCompensation = `UPDATE "compensation" SET "amount"=$1,"currency"=$2,"reason"=$3,"effective_to"=$4 WHERE "id"=$5`
+)
+
+// currentSalary is the amount of the compensation record in effect today,
+// falling back to the salary stored on the employee row. Reads, filters and
+// sorts all use it so that they agree on the salary.
+const currentSalary = `COALESCE((SELECT "compensation"."amount" FROM "compensation" ` +
+	`WHERE "compensation"."employee_id" = "employee"."id" ` +
+	`AND "compensation"."effective_from" <= (NOW() AT TIME ZONE 'UTC')::date ` +
+	`AND ("compensation"."effective_to" IS NULL OR "compensation"."effective_to" > (NOW() AT TIME ZONE 'UTC')::date) ` +
+	`ORDER BY "compensation"."effective_from" DESC LIMIT 1), "employee"."salary")`
+
+// currentUpdatedAt is the later of the updated_at of the employee row and
+// the start of the compensation record in effect today. A scheduled salary
+// change thus updates the employee, and its version, on the day it takes
+// effect although the row is not written.
+const currentUpdatedAt = `GREATEST("employee"."updated_at", (SELECT MAX("compensation"."effective_from") FROM "compensation" ` +
+	`WHERE "compensation"."employee_id" = "employee"."id" ` +
+	`AND "compensation"."effective_from" <= (NOW() AT TIME ZONE 'UTC')::date)::timestamp)`
+
+type compensationRepository struct {
+	db *sql.DB
+}
+
+// NewCompensationRepository returns a CompensationRepository.
+func NewCompensationRepository(db *sql.DB) interfaces.CompensationRepository {
+	return &compensationRepository{
+		db: db,
+	}
+}
+
+func (r *compensationRepository) List(ctx context.Context, employeeID int) ([]*dto.Compensation, error) {
+	rows, err := executor(ctx, r.db).QueryContext(ctx, selectCompensation, employeeID)
+	if err != nil {
+		return nil, translateError(err, entityCompensation)
+	}
+	defer rows.Close()
+
+	var compensations []*dto.Compensation
+	for rows.Next() {
+		var (
+			c           dto.Compensation
+			effectiveTo null.Time
+		)
+		err := rows.Scan(&c.ID, &c.EmployeeID, &c.Amount, &c.Currency, &c.Reason, &c.EffectiveFrom, &effectiveTo, &c.CreatedAt)
+		if err != nil {
+			return nil, translateError(err, entityCompensation)
+		}
+		c.EffectiveTo = effectiveTo.Ptr()
+		compensations = append(compensations, &c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, translateError(err, entityCompensation)
+	}
+
+	return compensations, nil
+}
+
+func (r *compensationRepository) Create(ctx context.Context, compensation *dto.Compensation) error {
+	compensation.CreatedAt = now()
+	err := executor(ctx, r.db).QueryRowContext(ctx, insertCompensation,
+		compensation.EmployeeID,
+		compensation.Amount,
+		compensation.Currency,
+		compensation.Reason,
+		compensation.EffectiveFrom,
+		null.TimeFromPtr(compensation.EffectiveTo),
+		compensation.CreatedAt,
+	).Scan(&compensation.ID)
+
+	return translateError(err, entityCompensation)
+}
+
+func (r *compensationRepository) Update(ctx context.Context, compensation *dto.Compensation) error {
+	result, err := executor(ctx, r.db).ExecContext(ctx, updateCompensation,
+		compensation.Amount,
+		compensation.Currency,
+		compensation.Reason,
+		null.TimeFromPtr(compensation.EffectiveTo),
+		compensation.ID,
+	)
+	if err != nil {
+		return translateError(err, entityCompensation)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return translateError(err, entityCompensation)
+	}
+	if rows == 0 {
+		return translateError(sql.ErrNoRows, entityCompensation)
+	}
+
+	return nil
+}
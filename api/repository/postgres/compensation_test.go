@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+	"employee-management/domain/dto"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompensationList(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	hired := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	raised := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta(selectCompensation)).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "employee_id", "amount", "currency", "reason", "effective_from", "effective_to", "created_at"}).
+			AddRow(1, 1, 60000, "USD", dto.ReasonHire, hired, raised, time.Now()).
+			AddRow(2, 1, 66000, "USD", dto.ReasonMerit, raised, nil, time.Now()))
+
+	repo := NewCompensationRepository(db)
+
+	timeline, err := repo.List(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Len(t, timeline, 2)
+	assert.Equal(t, raised, *timeline[0].EffectiveTo)
+	assert.Nil(t, timeline[1].EffectiveTo)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCompensationCreate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	effectiveFrom := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta(insertCompensation)).
+		WithArgs(1, 66000.0, "USD", dto.ReasonMerit, effectiveFrom, nil, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+
+	repo := NewCompensationRepository(db)
+	compensation := &dto.Compensation{EmployeeID: 1, Amount: 66000, Currency: "USD", Reason: dto.ReasonMerit, EffectiveFrom: effectiveFrom}
+
+	err = repo.Create(context.Background(), compensation)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), compensation.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCompensationUpdateNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(updateCompensation)).
+		WithArgs(66000.0, "USD", dto.ReasonMerit, nil, 9).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := NewCompensationRepository(db)
+
+	err = repo.Update(context.Background(), &dto.Compensation{ID: 9, Amount: 66000, Currency: "USD", Reason: dto.ReasonMerit})
+
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
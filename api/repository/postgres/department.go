@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"employee-management/api/repository/sqlboiler"
+	"employee-management/domain/dto"
+	"employee-management/domain/interfaces"
+	"employee-management/utils/convert"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+const entityDepartment = "department"
+
+type departmentRepository struct {
+	db *sql.DB
+}
+
+// NewDepartmentRepository returns a DepartmentRepository backed by the
+// generated sqlboiler models.
+func NewDepartmentRepository(db *sql.DB) interfaces.DepartmentRepository {
+	return &departmentRepository{
+		db: db,
+	}
+}
+
+func (r *departmentRepository) FindByID(ctx context.Context, departmentID int) (*dto.Department, error) {
+	department, err := sqlboiler.FindDepartment(ctx, executor(ctx, r.db), departmentID)
+	if err != nil {
+		return nil, translateError(err, entityDepartment)
+	}
+
+	return convert.ToDepartmentDTO(department), nil
+}
+
+func (r *departmentRepository) List(ctx context.Context, limit, offset int) ([]*dto.Department, error) {
+	mods := []qm.QueryMod{
+		qm.OrderBy(sqlboiler.DepartmentColumns.Name),
+		qm.OrderBy(sqlboiler.DepartmentColumns.ID),
+		qm.Limit(limit),
+	}
+	if offset > 0 {
+		mods = append(mods, qm.Offset(offset))
+	}
+
+	departments, err := sqlboiler.Departments(mods...).All(ctx, executor(ctx, r.db))
+	if err != nil {
+		return nil, translateError(err, entityDepartment)
+	}
+
+	return convert.ToDepartmentSliceDTO(departments), nil
+}
+
+func (r *departmentRepository) Count(ctx context.Context) (int64, error) {
+	count, err := sqlboiler.Departments().Count(ctx, executor(ctx, r.db))
+	if err != nil {
+		return 0, translateError(err, entityDepartment)
+	}
+
+	return count, nil
+}
+
+func (r *departmentRepository) Create(ctx context.Context, department *dto.Department) error {
+	model := convert.ToDepartmentModel(department)
+	model.CreatedAt = now()
+	model.UpdatedAt = model.CreatedAt
+	if err := model.Insert(boil.SkipTimestamps(ctx), executor(ctx, r.db), boil.Infer()); err != nil {
+		return translateError(err, entityDepartment)
+	}
+
+	*department = *convert.ToDepartmentDTO(model)
+	return nil
+}
+
+func (r *departmentRepository) Update(ctx context.Context, department *dto.Department) error {
+	model := convert.ToDepartmentModel(department)
+	model.UpdatedAt = now()
+	rows, err := model.Update(boil.SkipTimestamps(ctx), executor(ctx, r.db), boil.Whitelist(
+		sqlboiler.DepartmentColumns.Name,
+		sqlboiler.DepartmentColumns.UpdatedAt,
+	))
+	if err != nil {
+		return translateError(err, entityDepartment)
+	}
+	if rows == 0 {
+		return translateError(sql.ErrNoRows, entityDepartment)
+	}
+
+	*department = *convert.ToDepartmentDTO(model)
+	return nil
+}
+
+func (r *departmentRepository) Delete(ctx context.Context, departmentID int) error {
+	rows, err := sqlboiler.Departments(sqlboiler.DepartmentWhere.ID.EQ(departmentID)).DeleteAll(ctx, executor(ctx, r.db))
+	if err != nil {
+		return translateError(err, entityDepartment)
+	}
+	if rows == 0 {
+		return translateError(sql.ErrNoRows, entityDepartment)
+	}
+
+	return nil
+}
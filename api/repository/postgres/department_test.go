@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"employee-management/domain/apperror"
+	"employee-management/domain/dto"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var departmentColumns = []string{"id", "name", "created_at", "updated_at"}
+
+func TestDepartmentList(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "department".* FROM "department" ORDER BY name, id LIMIT 10 OFFSET 10;`)).
+		WillReturnRows(sqlmock.NewRows(departmentColumns).
+			AddRow(1, "Engineering", time.Now(), time.Now()).
+			AddRow(2, "Sales", time.Now(), time.Now()))
+
+	repo := NewDepartmentRepository(db)
+
+	departments, err := repo.List(context.Background(), 10, 10)
+
+	assert.NoError(t, err)
+	assert.Len(t, departments, 2)
+	assert.Equal(t, "Engineering", departments[0].Name)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDepartmentCreate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "department" ("name","created_at","updated_at") VALUES ($1,$2,$3) RETURNING "id"`)).
+		WithArgs("Engineering", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	repo := NewDepartmentRepository(db)
+	department := &dto.Department{Name: "Engineering"}
+
+	err = repo.Create(context.Background(), department)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, department.ID)
+	assert.False(t, department.CreatedAt.IsZero())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDepartmentDeleteNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "department" WHERE ("department"."id" = $1);`)).WithArgs(7).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := NewDepartmentRepository(db)
+
+	err = repo.Delete(context.Background(), 7)
+
+	appErr, _ := apperror.As(err)
+	assert.Equal(t, apperror.KindNotFound, appErr.Kind)
+	assert.Equal(t, "department_not_found", appErr.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindByIDLoadsDepartment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectEmployee + ` WHERE ("employee"."id" = $1) AND ("employee"."deleted_at" is null) LIMIT 1;`)).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(employeeColumns).AddRow(1, "John Doe", "Developer", 60000, time.Now(), time.Now(), nil, 2, nil))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "department" WHERE ("department"."id" IN ($1));`)).WithArgs(2).
+		WillReturnRows(sqlmock.NewRows(departmentColumns).AddRow(2, "Engineering", time.Now(), time.Now()))
+
+	repo := NewEmployeeRepository(db)
+
+	employee, err := repo.FindByID(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, *employee.DepartmentID)
+	assert.Equal(t, "Engineering", employee.Department.Name)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,442 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"employee-management/api/repository/sqlboiler"
+	"employee-management/domain/dto"
+	"employee-management/domain/interfaces"
+	"employee-management/utils/convert"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+const entityEmployee = "employee"
+
+type employeeRepository struct {
+	db *sql.DB
+}
+
+// NewEmployeeRepository returns an EmployeeRepository backed by the
+// generated sqlboiler models.
+func NewEmployeeRepository(db *sql.DB) interfaces.EmployeeRepository {
+	return &employeeRepository{
+		db: db,
+	}
+}
+
+func (r *employeeRepository) FindByID(ctx context.Context, employeeID int) (*dto.Employee, error) {
+	employee, err := sqlboiler.Employees(
+		employeeSelectMod(),
+		sqlboiler.EmployeeWhere.ID.EQ(employeeID),
+		sqlboiler.EmployeeWhere.DeletedAt.IsNull(),
+		qm.Load(sqlboiler.EmployeeRels.Department),
+	).One(ctx, executor(ctx, r.db))
+	if err != nil {
+		return nil, translateError(err, entityEmployee)
+	}
+
+	return convert.ToEmployeeDTO(employee), nil
+}
+
+func (r *employeeRepository) LockByID(ctx context.Context, employeeID int) (*dto.Employee, error) {
+	employee, err := r.lock(ctx, employeeID, sqlboiler.EmployeeWhere.DeletedAt.IsNull())
+	if err != nil {
+		return nil, err
+	}
+
+	return convert.ToEmployeeDTO(employee), nil
+}
+
+func (r *employeeRepository) LockSharedByID(ctx context.Context, employeeID int) (*dto.Employee, error) {
+	employee, err := sqlboiler.Employees(
+		employeeSelectMod(),
+		sqlboiler.EmployeeWhere.ID.EQ(employeeID),
+		sqlboiler.EmployeeWhere.DeletedAt.IsNull(),
+		qm.For("KEY SHARE"),
+	).One(ctx, executor(ctx, r.db))
+	if err != nil {
+		return nil, translateError(err, entityEmployee)
+	}
+
+	return convert.ToEmployeeDTO(employee), nil
+}
+
+func (r *employeeRepository) LockDeletedByID(ctx context.Context, employeeID int) (*dto.Employee, error) {
+	employee, err := r.lock(ctx, employeeID, sqlboiler.EmployeeWhere.DeletedAt.IsNotNull())
+	if err != nil {
+		return nil, err
+	}
+
+	return convert.ToEmployeeDTO(employee), nil
+}
+
+func (r *employeeRepository) List(ctx context.Context, query dto.EmployeeListQuery) ([]*dto.Employee, error) {
+	mods := append(employeeListMods(query), qm.Load(sqlboiler.EmployeeRels.Department))
+
+	employees, err := sqlboiler.Employees(mods...).All(ctx, executor(ctx, r.db))
+	if err != nil {
+		return nil, translateError(err, entityEmployee)
+	}
+
+	return convert.ToEmployeeSliceDTO(employees), nil
+}
+
+// Stream binds the rows one at a time as they arrive instead of loading
+// them all, and so cannot eager load departments.
+func (r *employeeRepository) Stream(ctx context.Context, query dto.EmployeeListQuery, fn func(*dto.Employee) error) error {
+	rows, err := sqlboiler.Employees(employeeListMods(query)...).QueryContext(ctx, executor(ctx, r.db))
+	if err != nil {
+		return translateError(err, entityEmployee)
+	}
+	defer rows.Close()
+
+	for {
+		model := new(sqlboiler.Employee)
+		if err := queries.Bind(rows, model); errors.Is(err, sql.ErrNoRows) {
+			break
+		} else if err != nil {
+			return translateError(err, entityEmployee)
+		}
+		if err := fn(convert.ToEmployeeDTO(model)); err != nil {
+			return err
+		}
+	}
+
+	return translateError(rows.Err(), entityEmployee)
+}
+
+func (r *employeeRepository) Count(ctx context.Context, filter dto.EmployeeFilter) (int64, error) {
+	count, err := sqlboiler.Employees(employeeFilterMods(filter)...).Count(ctx, executor(ctx, r.db))
+	if err != nil {
+		return 0, translateError(err, entityEmployee)
+	}
+
+	return count, nil
+}
+
+func (r *employeeRepository) Create(ctx context.Context, employee *dto.Employee) error {
+	model := convert.ToEmployeeModel(employee)
+	model.CreatedAt = model.CreatedAt.Truncate(time.Microsecond)
+	model.UpdatedAt = model.UpdatedAt.Truncate(time.Microsecond)
+	if err := model.Insert(ctx, executor(ctx, r.db), boil.Infer()); err != nil {
+		return translateError(err, entityEmployee)
+	}
+
+	*employee = *convert.ToEmployeeDTO(model)
+	return nil
+}
+
+func (r *employeeRepository) Update(ctx context.Context, employee *dto.Employee) error {
+	model := convert.ToEmployeeModel(employee)
+	// stamp updated_at with the precision postgres stores, so that the
+	// version of the returned employee matches the stored row
+	model.UpdatedAt = now()
+	rows, err := model.Update(boil.SkipTimestamps(ctx), executor(ctx, r.db), boil.Whitelist(
+		sqlboiler.EmployeeColumns.Name,
+		sqlboiler.EmployeeColumns.Position,
+		sqlboiler.EmployeeColumns.Salary,
+		sqlboiler.EmployeeColumns.DepartmentID,
+		sqlboiler.EmployeeColumns.ManagerID,
+		sqlboiler.EmployeeColumns.UpdatedAt,
+	))
+	if err != nil {
+		return translateError(err, entityEmployee)
+	}
+	if rows == 0 {
+		return translateError(sql.ErrNoRows, entityEmployee)
+	}
+
+	*employee = *convert.ToEmployeeDTO(model)
+	return nil
+}
+
+// Delete soft deletes an employee by stamping deleted_at. Like Restore and
+// Purge it changes a loaded model rather than using UpdateAll, so that the
+// model hooks see the change.
+func (r *employeeRepository) Delete(ctx context.Context, employeeID int) error {
+	model, err := r.lock(ctx, employeeID, sqlboiler.EmployeeWhere.DeletedAt.IsNull())
+	if err != nil {
+		return err
+	}
+
+	model.DeletedAt = null.TimeFrom(now())
+	model.UpdatedAt = model.DeletedAt.Time
+	return r.updateDeletedAt(ctx, model)
+}
+
+func (r *employeeRepository) Restore(ctx context.Context, employeeID int) (*dto.Employee, error) {
+	model, err := r.lock(ctx, employeeID, sqlboiler.EmployeeWhere.DeletedAt.IsNotNull())
+	if err != nil {
+		return nil, err
+	}
+
+	model.DeletedAt = null.Time{}
+	model.UpdatedAt = now()
+	if err := r.updateDeletedAt(ctx, model); err != nil {
+		return nil, err
+	}
+
+	return convert.ToEmployeeDTO(model), nil
+}
+
+// Purge removes an employee, deleted or not, for good.
+func (r *employeeRepository) Purge(ctx context.Context, employeeID int) error {
+	model, err := r.lock(ctx, employeeID)
+	if err != nil {
+		return err
+	}
+	if err := r.detachDeletedReports(ctx, employeeID); err != nil {
+		return err
+	}
+
+	if _, err := model.Delete(ctx, executor(ctx, r.db)); err != nil {
+		return translateError(err, entityEmployee)
+	}
+
+	return nil
+}
+
+// detachDeletedReports clears manager_id of the soft deleted employees
+// reporting to managerID, one model at a time so that the audit hooks see
+// it. Reports that are not deleted keep their manager, so purging it fails
+// on the foreign key.
+func (r *employeeRepository) detachDeletedReports(ctx context.Context, managerID int) error {
+	reports, err := sqlboiler.Employees(
+		sqlboiler.EmployeeWhere.ManagerID.EQ(null.IntFrom(managerID)),
+		sqlboiler.EmployeeWhere.DeletedAt.IsNotNull(),
+		qm.OrderBy(orderClause(sqlboiler.EmployeeColumns.ID, false)),
+		qm.For("UPDATE"),
+	).All(ctx, executor(ctx, r.db))
+	if err != nil {
+		return translateError(err, entityEmployee)
+	}
+
+	for _, report := range reports {
+		report.ManagerID = null.Int{}
+		report.UpdatedAt = now()
+		_, err := report.Update(boil.SkipTimestamps(ctx), executor(ctx, r.db), boil.Whitelist(
+			sqlboiler.EmployeeColumns.ManagerID,
+			sqlboiler.EmployeeColumns.UpdatedAt,
+		))
+		if err != nil {
+			return translateError(err, entityEmployee)
+		}
+	}
+	return nil
+}
+
+// lock loads and locks the employee matching mods.
+func (r *employeeRepository) lock(ctx context.Context, employeeID int, mods ...qm.QueryMod) (*sqlboiler.Employee, error) {
+	mods = append([]qm.QueryMod{employeeSelectMod(), sqlboiler.EmployeeWhere.ID.EQ(employeeID)}, mods...)
+	model, err := sqlboiler.Employees(append(mods, qm.For("UPDATE"))...).One(ctx, executor(ctx, r.db))
+	if err != nil {
+		return nil, translateError(err, entityEmployee)
+	}
+
+	return model, nil
+}
+
+// updateDeletedAt stores deleted_at and updated_at, so that deleting and
+// restoring an employee changes its version.
+func (r *employeeRepository) updateDeletedAt(ctx context.Context, model *sqlboiler.Employee) error {
+	_, err := model.Update(boil.SkipTimestamps(ctx), executor(ctx, r.db), boil.Whitelist(
+		sqlboiler.EmployeeColumns.DeletedAt,
+		sqlboiler.EmployeeColumns.UpdatedAt,
+	))
+	return translateError(err, entityEmployee)
+}
+
+// employeeListMods selects, orders and limits the employees of a list
+// query. A zero Limit selects every row.
+func employeeListMods(query dto.EmployeeListQuery) []qm.QueryMod {
+	order := employeeOrderFields(query.Sort)
+	mods := append([]qm.QueryMod{employeeSelectMod()}, employeeFilterMods(query.Filter)...)
+	if query.After != nil {
+		mods = append(mods, employeeKeysetMod(order, query.After))
+	}
+	for _, field := range order {
+		mods = append(mods, qm.OrderBy(orderClause(field.column, field.desc)))
+	}
+	if query.Limit > 0 {
+		mods = append(mods, qm.Limit(query.Limit))
+	}
+	if query.Offset > 0 {
+		mods = append(mods, qm.Offset(query.Offset))
+	}
+	return mods
+}
+
+// employeeFilterMods translates a filter into where clauses.
+func employeeFilterMods(filter dto.EmployeeFilter) []qm.QueryMod {
+	var mods []qm.QueryMod
+
+	switch filter.Deleted {
+	case dto.DeletedExclude:
+		mods = append(mods, sqlboiler.EmployeeWhere.DeletedAt.IsNull())
+	case dto.DeletedOnly:
+		mods = append(mods, sqlboiler.EmployeeWhere.DeletedAt.IsNotNull())
+	}
+
+	switch len(filter.Positions) {
+	case 0:
+	case 1:
+		mods = append(mods, sqlboiler.EmployeeWhere.Position.EQ(filter.Positions[0]))
+	default:
+		mods = append(mods, sqlboiler.EmployeeWhere.Position.IN(filter.Positions))
+	}
+	if filter.SalaryMin != nil {
+		mods = append(mods, qm.Where(currentSalary+" >= ?", *filter.SalaryMin))
+	}
+	if filter.SalaryMax != nil {
+		mods = append(mods, qm.Where(currentSalary+" <= ?", *filter.SalaryMax))
+	}
+	if len(filter.DepartmentIDs) > 0 {
+		mods = append(mods, sqlboiler.EmployeeWhere.DepartmentID.IN(filter.DepartmentIDs))
+	}
+	if filter.NameContains != "" {
+		mods = append(mods, sqlboiler.EmployeeWhere.Name.ILIKE("%"+escapeLike(filter.NameContains)+"%"))
+	}
+	if filter.CreatedFrom != nil {
+		mods = append(mods, sqlboiler.EmployeeWhere.CreatedAt.GTE(*filter.CreatedFrom))
+	}
+	if filter.CreatedTo != nil {
+		mods = append(mods, sqlboiler.EmployeeWhere.CreatedAt.LTE(*filter.CreatedTo))
+	}
+	if filter.UpdatedFrom != nil {
+		mods = append(mods, qm.Where(currentUpdatedAt+" >= ?", *filter.UpdatedFrom))
+	}
+	if filter.UpdatedTo != nil {
+		mods = append(mods, qm.Where(currentUpdatedAt+" <= ?", *filter.UpdatedTo))
+	}
+
+	return mods
+}
+
+type orderField struct {
+	column string
+	desc   bool
+}
+
+// employeeOrderFields orders by the requested fields and then by id, so
+// that rows with equal sort keys keep a stable order across pages.
+func employeeOrderFields(sort []dto.SortField) []orderField {
+	var fields []orderField
+	for _, field := range sort {
+		column := dto.EmployeeSortFields[field.Field]
+		fields = append(fields, orderField{column: column, desc: field.Desc})
+		if column == sqlboiler.EmployeeColumns.ID {
+			// id is unique, later fields cannot change the order
+			return fields
+		}
+	}
+
+	return append(fields, orderField{column: sqlboiler.EmployeeColumns.ID})
+}
+
+// employeeKeysetMod selects the rows ordered after the given employee:
+// (a > $1) OR (a = $1 AND b > $2) OR ..., with < for descending fields.
+func employeeKeysetMod(order []orderField, after *dto.Employee) qm.QueryMod {
+	var (
+		clauses []string
+		args    []interface{}
+	)
+	for i, field := range order {
+		var parts []string
+		for _, equal := range order[:i] {
+			parts = append(parts, fmt.Sprintf("%s = ?", columnRef(equal.column)))
+			args = append(args, employeeValue(after, equal.column))
+		}
+		op := ">"
+		if field.desc {
+			op = "<"
+		}
+		parts = append(parts, fmt.Sprintf("%s %s ?", columnRef(field.column), op))
+		args = append(args, employeeValue(after, field.column))
+		clauses = append(clauses, "("+strings.Join(parts, " AND ")+")")
+	}
+
+	return qm.Where(strings.Join(clauses, " OR "), args...)
+}
+
+// employeeValue returns the value of column in e.
+func employeeValue(e *dto.Employee, column string) interface{} {
+	switch column {
+	case sqlboiler.EmployeeColumns.Name:
+		return e.Name
+	case sqlboiler.EmployeeColumns.Position:
+		return e.Position
+	case sqlboiler.EmployeeColumns.Salary:
+		return e.Salary
+	case sqlboiler.EmployeeColumns.CreatedAt:
+		return e.CreatedAt
+	case sqlboiler.EmployeeColumns.UpdatedAt:
+		return e.UpdatedAt
+	default:
+		return e.ID
+	}
+}
+
+// employeeSelectMod selects every employee column, with the salary taken
+// from the compensation timeline.
+func employeeSelectMod() qm.QueryMod {
+	return qm.Select(employeeSelectList()...)
+}
+
+// employeeSelectList is the select list of employeeSelectMod.
+func employeeSelectList() []string {
+	columns := make([]string, 0, len(employeeSelectColumns))
+	for _, column := range employeeSelectColumns {
+		columns = append(columns, columnRef(column)+" AS "+fmt.Sprintf("%q", column))
+	}
+	return columns
+}
+
+// employeeSelectColumns are the columns of the employee model.
+var employeeSelectColumns = []string{
+	sqlboiler.EmployeeColumns.ID,
+	sqlboiler.EmployeeColumns.Name,
+	sqlboiler.EmployeeColumns.Position,
+	sqlboiler.EmployeeColumns.Salary,
+	sqlboiler.EmployeeColumns.CreatedAt,
+	sqlboiler.EmployeeColumns.UpdatedAt,
+	sqlboiler.EmployeeColumns.DeletedAt,
+	sqlboiler.EmployeeColumns.DepartmentID,
+	sqlboiler.EmployeeColumns.ManagerID,
+}
+
+// columnRef returns the SQL expression of an employee column.
+func columnRef(column string) string {
+	switch column {
+	case sqlboiler.EmployeeColumns.Salary:
+		return currentSalary
+	case sqlboiler.EmployeeColumns.UpdatedAt:
+		return currentUpdatedAt
+	}
+	return fmt.Sprintf("%q.%q", sqlboiler.TableNames.Employee, column)
+}
+
+func orderClause(column string, desc bool) string {
+	clause := columnRef(column)
+	if desc {
+		return clause + " DESC"
+	}
+	return clause + " ASC"
+}
+
+// now returns the current time at microsecond precision.
+func now() time.Time {
+	return time.Now().In(boil.GetLocation()).Truncate(time.Microsecond)
+}
+
+// escapeLike escapes the LIKE wildcards in s.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
@@ -0,0 +1,422 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"employee-management/domain/apperror"
+	"employee-management/domain/dto"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+var employeeColumns = []string{"id", "name", "position", "salary", "created_at", "updated_at", "deleted_at", "department_id", "manager_id"}
+
+// selectEmployee is the select list of every employee read.
+var selectEmployee = `SELECT "employee"."id" AS "id", "employee"."name" AS "name", "employee"."position" AS "position", ` +
+	currentSalary + ` AS "salary", "employee"."created_at" AS "created_at", ` + currentUpdatedAt + ` AS "updated_at", ` +
+	`"employee"."deleted_at" AS "deleted_at", "employee"."department_id" AS "department_id", "employee"."manager_id" AS "manager_id" FROM "employee"`
+
+func TestFindByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	employeeID := 1
+	rows := sqlmock.NewRows(employeeColumns).
+		AddRow(employeeID, "John Doe", "Developer", 60000, time.Now(), time.Now(), nil, nil, nil)
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectEmployee + ` WHERE ("employee"."id" = $1) AND ("employee"."deleted_at" is null) LIMIT 1;`)).WithArgs(employeeID).WillReturnRows(rows)
+
+	repo := NewEmployeeRepository(db)
+
+	employee, err := repo.FindByID(context.Background(), employeeID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "John Doe", employee.Name)
+	assert.Equal(t, "Developer", employee.Position)
+	assert.Equal(t, float64(60000), employee.Salary)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindByIDNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectEmployee + ` WHERE ("employee"."id" = $1) AND ("employee"."deleted_at" is null) LIMIT 1;`)).WithArgs(7).
+		WillReturnRows(sqlmock.NewRows(employeeColumns))
+
+	repo := NewEmployeeRepository(db)
+
+	_, err = repo.FindByID(context.Background(), 7)
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.Equal(t, apperror.KindNotFound, apperror.KindOf(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLockByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectEmployee + ` WHERE ("employee"."id" = $1) AND ("employee"."deleted_at" is null) LIMIT 1 FOR UPDATE;`)).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(employeeColumns).AddRow(1, "John Doe", "Developer", 60000, time.Now(), time.Now(), nil, nil, nil))
+
+	repo := NewEmployeeRepository(db)
+
+	employee, err := repo.LockByID(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "John Doe", employee.Name)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLockSharedByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectEmployee + ` WHERE ("employee"."id" = $1) AND ("employee"."deleted_at" is null) LIMIT 1 FOR KEY SHARE;`)).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(employeeColumns).AddRow(1, "John Doe", "Developer", 60000, time.Now(), time.Now(), nil, nil, nil))
+
+	repo := NewEmployeeRepository(db)
+
+	employee, err := repo.LockSharedByID(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "John Doe", employee.Name)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestList(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	rows := sqlmock.NewRows(employeeColumns).
+		AddRow(1, "John Doe", "Developer", 60000, time.Now(), time.Now(), nil, nil, nil).
+		AddRow(2, "Jane Smith", "Manager", 80000, time.Now(), time.Now(), nil, nil, nil)
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectEmployee + ` WHERE ("employee"."deleted_at" is null) ORDER BY "employee"."id" ASC LIMIT 10 OFFSET 5`)).WillReturnRows(rows)
+
+	repo := NewEmployeeRepository(db)
+
+	employees, err := repo.List(context.Background(), dto.EmployeeListQuery{Limit: 10, Offset: 5})
+
+	assert.NoError(t, err)
+	assert.Len(t, employees, 2)
+	assert.Equal(t, "John Doe", employees[0].Name)
+	assert.Equal(t, "Jane Smith", employees[1].Name)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStream(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	rows := sqlmock.NewRows(employeeColumns).
+		AddRow(1, "John Doe", "Developer", 60000, time.Now(), time.Now(), nil, nil, nil).
+		AddRow(2, "Jane Smith", "Manager", 80000, time.Now(), time.Now(), nil, nil, nil)
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectEmployee + ` WHERE ("employee"."deleted_at" is null) ORDER BY "employee"."name" ASC, "employee"."id" ASC;`)).WillReturnRows(rows)
+
+	repo := NewEmployeeRepository(db)
+
+	var names []string
+	err = repo.Stream(context.Background(), dto.EmployeeListQuery{Sort: []dto.SortField{{Field: "name"}}}, func(e *dto.Employee) error {
+		names = append(names, e.Name)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"John Doe", "Jane Smith"}, names)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListFiltersAndSorts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	salaryMin, salaryMax := 50000.0, 90000.0
+	createdFrom := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	query := dto.EmployeeListQuery{
+		Filter: dto.EmployeeFilter{
+			Positions:    []string{"Developer", "Manager"},
+			SalaryMin:    &salaryMin,
+			SalaryMax:    &salaryMax,
+			NameContains: "50%_jo",
+			CreatedFrom:  &createdFrom,
+		},
+		Sort:  []dto.SortField{{Field: "salary"}, {Field: "created_at", Desc: true}},
+		Limit: 20,
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectEmployee+` WHERE ("employee"."deleted_at" is null) AND ("employee"."position" IN ($1,$2)) AND (`+currentSalary+` >= $3) AND (`+currentSalary+` <= $4) AND ("employee"."name" ILIKE $5) AND ("employee"."created_at" >= $6) ORDER BY `+currentSalary+` ASC, "employee"."created_at" DESC, "employee"."id" ASC LIMIT 20`)).
+		WithArgs("Developer", "Manager", salaryMin, salaryMax, `%50\%\_jo%`, createdFrom).
+		WillReturnRows(sqlmock.NewRows(employeeColumns))
+
+	repo := NewEmployeeRepository(db)
+
+	employees, err := repo.List(context.Background(), query)
+
+	assert.NoError(t, err)
+	assert.Empty(t, employees)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	employee := &dto.Employee{
+		Name:      "John Doe",
+		Position:  "Developer",
+		Salary:    60000,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "employee" ("name","position","salary","created_at","updated_at") VALUES ($1,$2,$3,$4,$5) RETURNING "id","deleted_at","department_id","manager_id"`)).
+		WithArgs(employee.Name, employee.Position, employee.Salary, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "deleted_at", "department_id", "manager_id"}).AddRow(1, nil, nil, nil))
+
+	repo := NewEmployeeRepository(db)
+
+	err = repo.Create(context.Background(), employee)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, employee.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	employee := &dto.Employee{
+		ID:       1,
+		Name:     "John Doe Updated",
+		Position: "Senior Developer",
+		Salary:   70000,
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "employee" SET "name"=$1,"position"=$2,"salary"=$3,"department_id"=$4,"manager_id"=$5,"updated_at"=$6 WHERE "id"=$7`)).
+		WithArgs(employee.Name, employee.Position, employee.Salary, nil, nil, sqlmock.AnyArg(), employee.ID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	repo := NewEmployeeRepository(db)
+
+	err = repo.Update(context.Background(), employee)
+
+	assert.NoError(t, err)
+	assert.False(t, employee.UpdatedAt.IsZero())
+	assert.Equal(t, employee.UpdatedAt.Truncate(time.Microsecond), employee.UpdatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDelete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	employeeID := 1
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectEmployee + ` WHERE ("employee"."id" = $1) AND ("employee"."deleted_at" is null) LIMIT 1 FOR UPDATE;`)).
+		WithArgs(employeeID).
+		WillReturnRows(sqlmock.NewRows(employeeColumns))
+
+	repo := NewEmployeeRepository(db)
+
+	err = repo.Delete(context.Background(), employeeID)
+
+	assert.Equal(t, apperror.KindNotFound, apperror.KindOf(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRestore(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectEmployee + ` WHERE ("employee"."id" = $1) AND ("employee"."deleted_at" is not null) LIMIT 1 FOR UPDATE;`)).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(employeeColumns).AddRow(1, "John Doe", "Developer", 60000, time.Now(), time.Now(), time.Now(), nil, nil))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "employee" SET "deleted_at"=$1,"updated_at"=$2 WHERE "id"=$3`)).
+		WithArgs(nil, sqlmock.AnyArg(), 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := NewEmployeeRepository(db)
+
+	employee, err := repo.Restore(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Nil(t, employee.DeletedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurge(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectEmployee + ` WHERE ("employee"."id" = $1) LIMIT 1 FOR UPDATE;`)).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(employeeColumns).AddRow(1, "John Doe", "Developer", 60000, time.Now(), time.Now(), nil, nil, nil))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "employee".* FROM "employee" WHERE ("employee"."manager_id" = $1) AND ("employee"."deleted_at" is not null) ORDER BY "employee"."id" ASC FOR UPDATE;`)).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(employeeColumns).AddRow(2, "Jane Doe", "Developer", 50000, time.Now(), time.Now(), time.Now(), nil, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "employee" SET "manager_id"=$1,"updated_at"=$2 WHERE "id"=$3`)).
+		WithArgs(nil, sqlmock.AnyArg(), 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "employee" WHERE "id"=$1`)).
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := NewEmployeeRepository(db)
+
+	err = repo.Purge(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateDuplicate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "employee"`)).
+		WillReturnError(&pq.Error{Code: pqUniqueViolation})
+
+	repo := NewEmployeeRepository(db)
+
+	err = repo.Create(context.Background(), &dto.Employee{Name: "John Doe", Position: "Developer", Salary: 60000})
+
+	assert.Equal(t, apperror.KindConflict, apperror.KindOf(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWithinTransaction(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(selectEmployee + ` WHERE ("employee"."id" = $1) AND ("employee"."deleted_at" is null) LIMIT 1;`)).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(employeeColumns).AddRow(1, "John Doe", "Developer", 60000, time.Now(), time.Now(), nil, nil, nil))
+	mock.ExpectExec("RELEASE SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	repo := NewEmployeeRepository(db)
+	transactor := NewTransactor(db)
+
+	err = transactor.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		// nested calls join the outer transaction in a savepoint
+		return transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			_, err := repo.FindByID(ctx, 1)
+			return err
+		})
+	})
+	assert.NoError(t, err)
+
+	failure := errors.New("boom")
+	err = transactor.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		return failure
+	})
+	assert.ErrorIs(t, err, failure)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNestedTransactionRollsBackToSavepoint(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ROLLBACK TO SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("RELEASE SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	transactor := NewTransactor(db)
+	failed := errors.New("row failed")
+
+	err = transactor.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		assert.ErrorIs(t, transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			return failed
+		}), failed)
+		return transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			return nil
+		})
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListAfterCursor(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	query := dto.EmployeeListQuery{
+		Sort:  []dto.SortField{{Field: "salary", Desc: true}},
+		After: &dto.Employee{ID: 4, Salary: 60000},
+		Limit: 11,
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectEmployee+` WHERE ("employee"."deleted_at" is null) AND ((`+currentSalary+` < $1) OR (`+currentSalary+` = $2 AND "employee"."id" > $3)) ORDER BY `+currentSalary+` DESC, "employee"."id" ASC LIMIT 11`)).
+		WithArgs(60000.0, 60000.0, 4).
+		WillReturnRows(sqlmock.NewRows(employeeColumns))
+
+	repo := NewEmployeeRepository(db)
+
+	_, err = repo.List(context.Background(), query)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
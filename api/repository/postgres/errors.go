@@ -0,0 +1,41 @@
+package postgres
+
+import (
+	"database/sql"
+	"employee-management/domain/apperror"
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+// postgres error codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	pqForeignKeyViolation = "23503"
+	pqUniqueViolation     = "23505"
+	pqCheckViolation      = "23514"
+)
+
+// translateError converts storage errors into domain errors about entity.
+func translateError(err error, entity string) error {
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return apperror.Wrap(err, apperror.KindNotFound, entity+"_not_found", entity+" not found")
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case pqUniqueViolation:
+			return apperror.Wrap(err, apperror.KindConflict, entity+"_already_exists", entity+" already exists")
+		case pqForeignKeyViolation:
+			return apperror.Wrap(err, apperror.KindConflict, entity+"_reference_conflict", entity+" references or is referenced by another record")
+		case pqCheckViolation:
+			return apperror.Wrap(err, apperror.KindValidation, entity+"_check_violation", entity+" violates a database constraint")
+		}
+	}
+
+	return err
+}
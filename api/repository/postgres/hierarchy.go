@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"context"
+	"employee-management/api/repository/sqlboiler"
+	"employee-management/domain/dto"
+	"employee-management/utils/convert"
+	"fmt"
+
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// hierarchyLockKey identifies the advisory lock taken by LockHierarchy.
+const hierarchyLockKey = "employee.manager_id"
+
+func (r *employeeRepository) LockHierarchy(ctx context.Context) error {
+	_, err := queries.Raw(`SELECT pg_advisory_xact_lock(hashtext($1))`, hierarchyLockKey).ExecContext(ctx, executor(ctx, r.db))
+	return translateError(err, entityEmployee)
+}
+
+func (r *employeeRepository) DirectReports(ctx context.Context, managerID int) ([]*dto.Employee, error) {
+	employees, err := sqlboiler.Employees(
+		employeeSelectMod(),
+		sqlboiler.EmployeeWhere.ManagerID.EQ(null.IntFrom(managerID)),
+		sqlboiler.EmployeeWhere.DeletedAt.IsNull(),
+		qm.OrderBy(orderClause(sqlboiler.EmployeeColumns.Name, false)),
+		qm.OrderBy(orderClause(sqlboiler.EmployeeColumns.ID, false)),
+		qm.Load(sqlboiler.EmployeeRels.Department),
+	).All(ctx, executor(ctx, r.db))
+	if err != nil {
+		return nil, translateError(err, entityEmployee)
+	}
+
+	return convert.ToEmployeeSliceDTO(employees), nil
+}
+
+// The hierarchy queries walk manager_id with recursive CTEs. UNION rather
+// than UNION ALL makes them terminate even if the data holds a cycle.
+const (
+	managersCTE = `RECURSIVE "managers" ("id") AS (` +
+		`SELECT "manager_id" FROM "employee" WHERE "id" = ? AND "manager_id" IS NOT NULL ` +
+		`UNION SELECT "employee"."manager_id" FROM "employee" JOIN "managers" ON "employee"."id" = "managers"."id" WHERE "employee"."manager_id" IS NOT NULL)`
+	subtreeCTE = `RECURSIVE "subtree" ("id") AS (` +
+		`SELECT "id" FROM "employee" WHERE "id" = ? AND "deleted_at" IS NULL ` +
+		`UNION SELECT "employee"."id" FROM "employee" JOIN "subtree" ON "employee"."manager_id" = "subtree"."id" WHERE "employee"."deleted_at" IS NULL)`
+)
+
+func (r *employeeRepository) Managers(ctx context.Context, employeeID int) ([]*dto.Employee, error) {
+	return r.hierarchy(ctx, qm.With(managersCTE, employeeID), `"managers"`)
+}
+
+func (r *employeeRepository) Subtree(ctx context.Context, employeeID int) ([]*dto.Employee, error) {
+	return r.hierarchy(ctx, qm.With(subtreeCTE, employeeID), `"subtree"`)
+}
+
+// hierarchy lists the employees whose ids the cte lists.
+func (r *employeeRepository) hierarchy(ctx context.Context, cte qm.QueryMod, name string) ([]*dto.Employee, error) {
+	employees, err := sqlboiler.Employees(
+		cte,
+		employeeSelectMod(),
+		qm.InnerJoin(fmt.Sprintf(`%s ON %s."id" = %s`, name, name, columnRef(sqlboiler.EmployeeColumns.ID))),
+		sqlboiler.EmployeeWhere.DeletedAt.IsNull(),
+		qm.OrderBy(orderClause(sqlboiler.EmployeeColumns.Name, false)),
+		qm.OrderBy(orderClause(sqlboiler.EmployeeColumns.ID, false)),
+		qm.Load(sqlboiler.EmployeeRels.Department),
+	).All(ctx, executor(ctx, r.db))
+	if err != nil {
+		return nil, translateError(err, entityEmployee)
+	}
+
+	return convert.ToEmployeeSliceDTO(employees), nil
+}
@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManagers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`WITH RECURSIVE "managers" ("id") AS (` +
+		`SELECT "manager_id" FROM "employee" WHERE "id" = $1 AND "manager_id" IS NOT NULL ` +
+		`UNION SELECT "employee"."manager_id" FROM "employee" JOIN "managers" ON "employee"."id" = "managers"."id" WHERE "employee"."manager_id" IS NOT NULL) ` +
+		selectEmployee + ` INNER JOIN "managers" ON "managers"."id" = "employee"."id" WHERE ("employee"."deleted_at" is null) ORDER BY "employee"."name" ASC, "employee"."id" ASC;`)).
+		WithArgs(4).
+		WillReturnRows(sqlmock.NewRows(employeeColumns).
+			AddRow(1, "Ada", "CEO", 90000, time.Now(), time.Now(), nil, nil, nil).
+			AddRow(2, "Bob", "CTO", 80000, time.Now(), time.Now(), nil, nil, 1))
+
+	repo := NewEmployeeRepository(db)
+
+	managers, err := repo.Managers(context.Background(), 4)
+
+	assert.NoError(t, err)
+	assert.Len(t, managers, 2)
+	assert.Equal(t, 1, *managers[1].ManagerID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSubtree(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`WITH RECURSIVE "subtree" ("id") AS (` +
+		`SELECT "id" FROM "employee" WHERE "id" = $1 AND "deleted_at" IS NULL ` +
+		`UNION SELECT "employee"."id" FROM "employee" JOIN "subtree" ON "employee"."manager_id" = "subtree"."id" WHERE "employee"."deleted_at" IS NULL) ` +
+		selectEmployee + ` INNER JOIN "subtree" ON "subtree"."id" = "employee"."id" WHERE ("employee"."deleted_at" is null) ORDER BY "employee"."name" ASC, "employee"."id" ASC;`)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(employeeColumns).
+			AddRow(1, "Ada", "CEO", 90000, time.Now(), time.Now(), nil, nil, nil))
+
+	repo := NewEmployeeRepository(db)
+
+	subtree, err := repo.Subtree(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Len(t, subtree, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLockHierarchy(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_xact_lock(hashtext($1))`)).WithArgs(hierarchyLockKey).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := NewEmployeeRepository(db)
+
+	assert.NoError(t, repo.LockHierarchy(context.Background()))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
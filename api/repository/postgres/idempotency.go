@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"employee-management/domain/dto"
+	"employee-management/domain/interfaces"
+	"errors"
+	"sync/atomic"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+const entityIdempotencyKey = "idempotency_key"
+
+// The idempotency_keys table is accessed with plain SQL. A key is claimed
+// with an insert that only overwrites expired keys; the primary key makes
+// a concurrent claim of the same key wait for the claiming transaction.
+const (
+	idempotencyKeyColumns  = `"scope","key","request_hash","response","created_at","expires_at"`
+	claimIdempotencyKey    = `INSERT INTO "idempotency_keys" AS "k" ("scope","key","request_hash","created_at","expires_at") VALUES ($1,$2,$3,$4,$5) ON CONFLICT ("scope","key") DO UPDATE SET "request_hash"=EXCLUDED."request_hash","response"=NULL,"created_at"=EXCLUDED."created_at","expires_at"=EXCLUDED."expires_at" WHERE "k"."expires_at" <= EXCLUDED."created_at" RETURNING "key"`
+	selectIdempotencyKey   = `SELECT ` + idempotencyKeyColumns + ` FROM "idempotency_keys" WHERE "scope"=$1 AND "key"=$2`
+	saveIdempotentResponse = `UPDATE "idempotency_keys" SET "response"=$1 WHERE "scope"=$2 AND "key"=$3`
+	pruneIdempotencyKeys   = `DELETE FROM "idempotency_keys" WHERE "expires_at" <= $1`
+)
+
+// idempotencyPruneClaims is the number of claims between two removals of
+// the expired keys.
+const idempotencyPruneClaims = 1024
+
+type idempotencyKeyRepository struct {
+	db     *sql.DB
+	claims atomic.Int64
+}
+
+// NewIdempotencyKeyRepository returns an IdempotencyKeyRepository.
+func NewIdempotencyKeyRepository(db *sql.DB) interfaces.IdempotencyKeyRepository {
+	return &idempotencyKeyRepository{
+		db: db,
+	}
+}
+
+func (r *idempotencyKeyRepository) Claim(ctx context.Context, key *dto.IdempotencyKey) (*dto.IdempotencyKey, error) {
+	key.CreatedAt = now()
+	// expires_at has no time zone and is compared with created_at: store
+	// the instant in the same location rather than that of the caller
+	key.ExpiresAt = key.ExpiresAt.In(boil.GetLocation())
+	if r.claims.Add(1)%idempotencyPruneClaims == 0 {
+		// a failed clean up only leaves rows that are removed next time
+		_, _ = r.db.ExecContext(ctx, pruneIdempotencyKeys, key.CreatedAt)
+	}
+
+	for {
+		var claimed string
+		err := executor(ctx, r.db).QueryRowContext(ctx, claimIdempotencyKey,
+			key.Scope,
+			key.Key,
+			key.RequestHash,
+			key.CreatedAt,
+			key.ExpiresAt,
+		).Scan(&claimed)
+		if err == nil {
+			return nil, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, translateError(err, entityIdempotencyKey)
+		}
+
+		existing, err := scanIdempotencyKey(executor(ctx, r.db).QueryRowContext(ctx, selectIdempotencyKey, key.Scope, key.Key))
+		if errors.Is(err, sql.ErrNoRows) {
+			// pruned since the claim failed: claim it again
+			continue
+		}
+		if err != nil {
+			return nil, translateError(err, entityIdempotencyKey)
+		}
+		return existing, nil
+	}
+}
+
+func (r *idempotencyKeyRepository) SaveResponse(ctx context.Context, key *dto.IdempotencyKey) error {
+	_, err := executor(ctx, r.db).ExecContext(ctx, saveIdempotentResponse, []byte(key.Response), key.Scope, key.Key)
+	return translateError(err, entityIdempotencyKey)
+}
+
+func scanIdempotencyKey(row rowScanner) (*dto.IdempotencyKey, error) {
+	var (
+		key      dto.IdempotencyKey
+		response []byte
+	)
+	err := row.Scan(&key.Scope, &key.Key, &key.RequestHash, &response, &key.CreatedAt, &key.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	key.Response = response
+	return &key, nil
+}
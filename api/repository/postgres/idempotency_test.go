@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"employee-management/domain/dto"
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyKeyClaim(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	expiresAt := time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta(claimIdempotencyKey)).
+		WithArgs("alice", "7f1c", "hash", sqlmock.AnyArg(), expiresAt).
+		WillReturnRows(sqlmock.NewRows([]string{"key"}).AddRow("7f1c"))
+
+	repo := NewIdempotencyKeyRepository(db)
+	key := &dto.IdempotencyKey{Scope: "alice", Key: "7f1c", RequestHash: "hash", ExpiresAt: expiresAt}
+
+	stored, err := repo.Claim(context.Background(), key)
+
+	assert.NoError(t, err)
+	assert.Nil(t, stored)
+	assert.False(t, key.CreatedAt.IsZero())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIdempotencyKeyClaimStoresExpiryInUTC(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	expiresAt := time.Date(2025, 1, 2, 9, 0, 0, 0, time.FixedZone("-07:00", -7*60*60))
+	mock.ExpectQuery(regexp.QuoteMeta(claimIdempotencyKey)).
+		WithArgs("alice", "7f1c", "hash", sqlmock.AnyArg(), time.Date(2025, 1, 2, 16, 0, 0, 0, time.UTC)).
+		WillReturnRows(sqlmock.NewRows([]string{"key"}).AddRow("7f1c"))
+
+	repo := NewIdempotencyKeyRepository(db)
+	key := &dto.IdempotencyKey{Scope: "alice", Key: "7f1c", RequestHash: "hash", ExpiresAt: expiresAt}
+
+	_, err = repo.Claim(context.Background(), key)
+
+	assert.NoError(t, err)
+	assert.True(t, key.ExpiresAt.Equal(expiresAt))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIdempotencyKeyClaimReturnsStoredKey(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	createdAt := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	expiresAt := createdAt.Add(24 * time.Hour)
+	mock.ExpectQuery(regexp.QuoteMeta(claimIdempotencyKey)).
+		WithArgs("alice", "7f1c", "other", sqlmock.AnyArg(), expiresAt).
+		WillReturnRows(sqlmock.NewRows([]string{"key"}))
+	mock.ExpectQuery(regexp.QuoteMeta(selectIdempotencyKey)).
+		WithArgs("alice", "7f1c").
+		WillReturnRows(sqlmock.NewRows([]string{"scope", "key", "request_hash", "response", "created_at", "expires_at"}).
+			AddRow("alice", "7f1c", "hash", []byte(`{"id":7}`), createdAt, expiresAt))
+
+	repo := NewIdempotencyKeyRepository(db)
+
+	stored, err := repo.Claim(context.Background(), &dto.IdempotencyKey{Scope: "alice", Key: "7f1c", RequestHash: "other", ExpiresAt: expiresAt})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.IdempotencyKey{
+		Scope:       "alice",
+		Key:         "7f1c",
+		RequestHash: "hash",
+		Response:    json.RawMessage(`{"id":7}`),
+		CreatedAt:   createdAt,
+		ExpiresAt:   expiresAt,
+	}, stored)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIdempotencyKeySaveResponse(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(saveIdempotentResponse)).
+		WithArgs([]byte(`{"id":7}`), "alice", "7f1c").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := NewIdempotencyKeyRepository(db)
+
+	err = repo.SaveResponse(context.Background(), &dto.IdempotencyKey{Scope: "alice", Key: "7f1c", Response: json.RawMessage(`{"id":7}`)})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
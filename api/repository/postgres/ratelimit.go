@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"employee-management/domain/dto"
+	"employee-management/domain/interfaces"
+	"errors"
+	"sync/atomic"
+)
+
+const entityRateLimitBucket = "rate_limit_bucket"
+
+// The rate_limit_buckets table is accessed with plain SQL. A token is taken
+// with a single upsert that only writes when the refilled bucket holds at
+// least one token, so concurrent instances cannot overdraw a bucket. $2 is
+// the capacity and $3 the refill rate per second of the bucket.
+const (
+	bucketNow     = `(NOW() AT TIME ZONE 'UTC')`
+	bucketRefill  = `LEAST($2, "b"."tokens" + GREATEST(0, EXTRACT(EPOCH FROM (` + bucketNow + ` - "b"."updated_at"))) * $3)`
+	takeRateLimit = `INSERT INTO "rate_limit_buckets" AS "b" ("key","tokens","updated_at","full_at") ` +
+		`VALUES ($1, $2::float8 - 1, ` + bucketNow + `, ` + bucketNow + ` + make_interval(secs => 1 / $3::float8)) ` +
+		`ON CONFLICT ("key") DO UPDATE SET "tokens" = ` + bucketRefill + ` - 1, "updated_at" = ` + bucketNow + `, ` +
+		`"full_at" = ` + bucketNow + ` + make_interval(secs => ($2 - ` + bucketRefill + ` + 1) / $3) ` +
+		`WHERE ` + bucketRefill + ` >= 1 RETURNING "tokens"`
+	peekRateLimit  = `SELECT ` + bucketRefill + ` FROM "rate_limit_buckets" AS "b" WHERE "key"=$1`
+	pruneRateLimit = `DELETE FROM "rate_limit_buckets" WHERE "full_at" < ` + bucketNow
+)
+
+// rateLimitPruneTakes is the number of takes between two removals of the
+// buckets that are full again.
+const rateLimitPruneTakes = 1024
+
+type rateLimitStore struct {
+	db    *sql.DB
+	takes atomic.Int64
+}
+
+// NewRateLimitStore returns a RateLimitStore sharing the buckets between
+// every instance of the server through the database. Bucket times are
+// taken from the database clock, so the clocks of the instances do not
+// matter.
+func NewRateLimitStore(db *sql.DB) interfaces.RateLimitStore {
+	return &rateLimitStore{
+		db: db,
+	}
+}
+
+func (s *rateLimitStore) Take(ctx context.Context, key string, limit dto.RateLimit) (dto.RateLimitResult, error) {
+	if s.takes.Add(1)%rateLimitPruneTakes == 0 {
+		// a failed clean up only leaves rows that are removed next time
+		_, _ = s.db.ExecContext(ctx, pruneRateLimit)
+	}
+
+	var tokens float64
+	err := s.db.QueryRowContext(ctx, takeRateLimit, key, limit.Capacity(), limit.Rate()).Scan(&tokens)
+	if err == nil {
+		return limit.Result(tokens, true), nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return dto.RateLimitResult{}, translateError(err, entityRateLimitBucket)
+	}
+
+	// the bucket is empty: report when it will hold a token again
+	err = s.db.QueryRowContext(ctx, peekRateLimit, key, limit.Capacity(), limit.Rate()).Scan(&tokens)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return dto.RateLimitResult{}, translateError(err, entityRateLimitBucket)
+	}
+	return limit.Result(tokens, false), nil
+}
@@ -0,0 +1,41 @@
+package postgres
+
+import (
+	"context"
+	"employee-management/domain/dto"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitTake(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	limit := dto.RateLimit{Requests: 60, Period: time.Minute, Burst: 10}
+	mock.ExpectQuery(regexp.QuoteMeta(takeRateLimit)).WithArgs("*|ip:10.0.0.1", 10.0, 1.0).
+		WillReturnRows(sqlmock.NewRows([]string{"tokens"}).AddRow(8.5))
+	mock.ExpectQuery(regexp.QuoteMeta(takeRateLimit)).WithArgs("*|ip:10.0.0.1", 10.0, 1.0).
+		WillReturnRows(sqlmock.NewRows([]string{"tokens"}))
+	mock.ExpectQuery(regexp.QuoteMeta(peekRateLimit)).WithArgs("*|ip:10.0.0.1", 10.0, 1.0).
+		WillReturnRows(sqlmock.NewRows([]string{"tokens"}).AddRow(0.25))
+
+	store := NewRateLimitStore(db)
+
+	result, err := store.Take(context.Background(), "*|ip:10.0.0.1", limit)
+	assert.NoError(t, err)
+	assert.Equal(t, dto.RateLimitResult{Allowed: true, Limit: 10, Remaining: 8, Reset: 1500 * time.Millisecond}, result)
+
+	result, err = store.Take(context.Background(), "*|ip:10.0.0.1", limit)
+	assert.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+	assert.Equal(t, 750*time.Millisecond, result.RetryAfter)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+	"employee-management/api/repository/sqlboiler"
+	"employee-management/domain/dto"
+	"employee-management/utils/convert"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+// searchVector is the expression of the employee_search_idx index.
+const searchVector = `(setweight(to_tsvector('simple', "employee"."name"), 'A') || ` +
+	`setweight(to_tsvector('simple', "employee"."position"), 'B'))`
+
+// The search is plain SQL so that its values are bound as parameters the
+// planner sees: the indexes only serve conditions on known values. $1 is
+// the tsquery, $2 the text and $3 the limit.
+var (
+	searchRank       = `ts_rank(` + searchVector + `, to_tsquery('simple', $1))`
+	searchSimilarity = `GREATEST(word_similarity($2, "employee"."name"), word_similarity($2, "employee"."position"))`
+	searchEmployees  = fmt.Sprintf(`SELECT %s, %s AS "rank", %s AS "similarity", %s + %s AS "score" FROM "employee" `+
+		`WHERE "employee"."deleted_at" IS NULL AND (%s @@ to_tsquery('simple', $1) OR $2 <%% "employee"."name" OR $2 <%% "employee"."position") `+
+		`ORDER BY "score" DESC, "employee"."id" ASC LIMIT $3`,
+		strings.Join(employeeSelectList(), ", "), searchRank, searchSimilarity, searchRank, searchSimilarity, searchVector)
+)
+
+// setSimilarityThreshold sets the threshold of the <% operator until the
+// end of the transaction.
+const setSimilarityThreshold = `SELECT set_config('pg_trgm.word_similarity_threshold', $1, true)`
+
+// employeeSearchRow is a row of searchEmployees.
+type employeeSearchRow struct {
+	sqlboiler.Employee `boil:",bind"`
+	Rank               float64 `boil:"rank"`
+	Similarity         float64 `boil:"similarity"`
+	Score              float64 `boil:"score"`
+}
+
+func (r *employeeRepository) Search(ctx context.Context, query dto.EmployeeSearchQuery) ([]*dto.EmployeeSearchResult, error) {
+	exec := executor(ctx, r.db)
+	threshold := strconv.FormatFloat(query.MinSimilarity, 'f', -1, 64)
+	if _, err := queries.Raw(setSimilarityThreshold, threshold).ExecContext(ctx, exec); err != nil {
+		return nil, translateError(err, entityEmployee)
+	}
+
+	var rows []*employeeSearchRow
+	if err := queries.Raw(searchEmployees, prefixQuery(query.Words), query.Text, query.Limit).Bind(ctx, exec, &rows); err != nil {
+		return nil, translateError(err, entityEmployee)
+	}
+
+	results := make([]*dto.EmployeeSearchResult, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, &dto.EmployeeSearchResult{
+			Employee:   convert.ToEmployeeDTO(&row.Employee),
+			Score:      row.Score,
+			Rank:       row.Rank,
+			Similarity: row.Similarity,
+		})
+	}
+	return results, nil
+}
+
+// prefixQuery builds a tsquery matching documents that hold a word
+// starting with every one of words. The words must not contain tsquery
+// syntax.
+func prefixQuery(words []string) string {
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		terms = append(terms, word+":*")
+	}
+	return strings.Join(terms, " & ")
+}
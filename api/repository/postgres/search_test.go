@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"context"
+	"employee-management/domain/dto"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(setSimilarityThreshold)).
+		WithArgs("0.4").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(searchEmployees)).
+		WithArgs("jon:* & dev:*", "jon dev", 20).
+		WillReturnRows(sqlmock.NewRows(append(append([]string(nil), employeeColumns...), "rank", "similarity", "score")).
+			AddRow(3, "Jonathan Price", "Developer", 50000, time.Now(), time.Now(), nil, nil, nil, 0.3, 0.75, 1.05).
+			AddRow(1, "John Doe", "Developer", 60000, time.Now(), time.Now(), nil, nil, nil, 0, 0.5, 0.5))
+
+	repo := NewEmployeeRepository(db)
+
+	results, err := repo.Search(context.Background(), dto.EmployeeSearchQuery{
+		Text:          "jon dev",
+		Words:         []string{"jon", "dev"},
+		MinSimilarity: 0.4,
+		Limit:         20,
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, "Jonathan Price", results[0].Name)
+	assert.Equal(t, 1.05, results[0].Score)
+	assert.Equal(t, 0.3, results[0].Rank)
+	assert.Equal(t, 0.5, results[1].Similarity)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,123 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"employee-management/api/repository/sqlboiler"
+	"employee-management/domain/dto"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// currentSalaryJoin computes currentSalary once per employee for the
+// aggregates to share.
+const currentSalaryJoin = `LATERAL (SELECT ` + currentSalary + ` AS "amount") AS "current_salary" ON true`
+
+// salaryAggregates are the aggregates of the current salaries of a group
+// except for the requested percentiles, in the order SalaryStats scans
+// them.
+const salaryAggregates = `count(*), sum("current_salary"."amount"), avg("current_salary"."amount"), ` +
+	`percentile_cont(0.5) WITHIN GROUP (ORDER BY "current_salary"."amount"), ` +
+	`min("current_salary"."amount"), max("current_salary"."amount")`
+
+// SalaryStats aggregates the salaries in the database with one row per
+// group, ordered by the group values.
+func (r *employeeRepository) SalaryStats(ctx context.Context, query dto.EmployeeStatsQuery) ([]*dto.EmployeeStatsGroup, error) {
+	groupColumns := make([]string, 0, len(query.GroupBy))
+	for _, field := range query.GroupBy {
+		groupColumns = append(groupColumns, columnRef(dto.EmployeeGroupFields[field]))
+	}
+
+	selected := append(append([]string(nil), groupColumns...), salaryAggregates, percentileAggregate(query.Percentiles))
+	mods := append([]qm.QueryMod{
+		qm.Select(selected...),
+		qm.LeftOuterJoin(currentSalaryJoin),
+	}, employeeFilterMods(query.Filter)...)
+	for _, column := range groupColumns {
+		mods = append(mods, qm.GroupBy(column), qm.OrderBy(column))
+	}
+
+	rows, err := sqlboiler.Employees(mods...).QueryContext(ctx, executor(ctx, r.db))
+	if err != nil {
+		return nil, translateError(err, entityEmployee)
+	}
+	defer rows.Close()
+
+	var groups []*dto.EmployeeStatsGroup
+	for rows.Next() {
+		group, err := scanStatsGroup(rows, query)
+		if err != nil {
+			return nil, translateError(err, entityEmployee)
+		}
+		groups = append(groups, group)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, translateError(err, entityEmployee)
+	}
+
+	return groups, nil
+}
+
+// percentileAggregate computes every percentile in one call. The
+// percentiles are validated numbers, so they are written into the query
+// rather than bound: percentile_cont needs them to be constant.
+func percentileAggregate(percentiles []float64) string {
+	values := make([]string, 0, len(percentiles))
+	for _, percentile := range percentiles {
+		values = append(values, strconv.FormatFloat(percentile, 'f', -1, 64))
+	}
+	return fmt.Sprintf(`percentile_cont(ARRAY[%s]::double precision[]) WITHIN GROUP (ORDER BY "current_salary"."amount")`, strings.Join(values, ","))
+}
+
+func scanStatsGroup(rows *sql.Rows, query dto.EmployeeStatsQuery) (*dto.EmployeeStatsGroup, error) {
+	var (
+		group                               = &dto.EmployeeStatsGroup{Group: make(map[string]interface{}, len(query.GroupBy))}
+		values                              = make([]interface{}, len(query.GroupBy))
+		sum, mean, median, minimum, maximum sql.NullFloat64
+		percentiles                         pq.Float64Array
+	)
+	dest := make([]interface{}, 0, len(values)+7)
+	for i := range values {
+		dest = append(dest, &values[i])
+	}
+	dest = append(dest, &group.Headcount, &sum, &mean, &median, &minimum, &maximum, &percentiles)
+	if err := rows.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	for i, field := range query.GroupBy {
+		if text, ok := values[i].([]byte); ok {
+			values[i] = string(text)
+		}
+		group.Group[field] = values[i]
+	}
+	group.Salary = dto.SalaryStats{
+		Sum:         nullFloat(sum),
+		Mean:        nullFloat(mean),
+		Median:      nullFloat(median),
+		Min:         nullFloat(minimum),
+		Max:         nullFloat(maximum),
+		Percentiles: make([]dto.SalaryPercentile, 0, len(query.Percentiles)),
+	}
+	for i, percentile := range query.Percentiles {
+		stat := dto.SalaryPercentile{Percentile: percentile}
+		if i < len(percentiles) {
+			value := percentiles[i]
+			stat.Value = &value
+		}
+		group.Salary.Percentiles = append(group.Salary.Percentiles, stat)
+	}
+
+	return group, nil
+}
+
+func nullFloat(value sql.NullFloat64) *float64 {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Float64
+}
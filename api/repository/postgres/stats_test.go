@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+	"employee-management/domain/dto"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSalaryStats(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "employee"."position" as "employee.position", "employee"."department_id" as "employee.department_id", ` + salaryAggregates + `, ` +
+		`percentile_cont(ARRAY[0.1,0.9]::double precision[]) WITHIN GROUP (ORDER BY "current_salary"."amount") ` +
+		`FROM "employee" LEFT JOIN ` + currentSalaryJoin + ` WHERE ("employee"."deleted_at" is null) AND ("employee"."position" = $1) ` +
+		`GROUP BY "employee"."position", "employee"."department_id" ORDER BY "employee"."position", "employee"."department_id";`)).
+		WithArgs("Developer").
+		WillReturnRows(sqlmock.NewRows([]string{"position", "department_id", "count", "sum", "avg", "percentile_cont", "min", "max", "percentile_cont"}).
+			AddRow("Developer", 1, 2, 140000.0, 70000.0, 70000.0, 60000.0, 80000.0, "{62000,78000}").
+			AddRow("Developer", nil, 0, nil, nil, nil, nil, nil, nil))
+
+	repo := NewEmployeeRepository(db)
+
+	groups, err := repo.SalaryStats(context.Background(), dto.EmployeeStatsQuery{
+		Filter:      dto.EmployeeFilter{Positions: []string{"Developer"}},
+		GroupBy:     []string{"position", "department_id"},
+		Percentiles: []float64{0.1, 0.9},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, groups, 2)
+	assert.Equal(t, map[string]interface{}{"position": "Developer", "department_id": int64(1)}, groups[0].Group)
+	assert.Equal(t, int64(2), groups[0].Headcount)
+	assert.Equal(t, 70000.0, *groups[0].Salary.Median)
+	assert.Equal(t, 78000.0, *groups[0].Salary.Percentiles[1].Value)
+	assert.Equal(t, 0.9, groups[0].Salary.Percentiles[1].Percentile)
+	assert.Nil(t, groups[1].Group["department_id"])
+	assert.Nil(t, groups[1].Salary.Mean)
+	assert.Nil(t, groups[1].Salary.Percentiles[0].Value)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"employee-management/domain/interfaces"
+	"fmt"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+type txKey struct{}
+
+// savepointKey holds the nesting depth of the running savepoint.
+type savepointKey struct{}
+
+type transactor struct {
+	db *sql.DB
+}
+
+// NewTransactor returns a Transactor that runs work inside a postgres transaction.
+func NewTransactor(db *sql.DB) interfaces.Transactor {
+	return &transactor{
+		db: db,
+	}
+}
+
+// WithinTransaction runs fn with a context carrying the transaction. Nested
+// calls join the transaction that is already running inside a savepoint.
+func (t *transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return withinSavepoint(ctx, tx, fn)
+	}
+
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// withinSavepoint runs fn inside a savepoint of tx. When fn fails only its
+// own work is rolled back and tx stays usable.
+func withinSavepoint(ctx context.Context, tx *sql.Tx, fn func(ctx context.Context) error) error {
+	depth, _ := ctx.Value(savepointKey{}).(int)
+	depth++
+	name := fmt.Sprintf("sp_%d", depth)
+
+	if _, err := tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return err
+	}
+	if err := fn(context.WithValue(ctx, savepointKey{}, depth)); err != nil {
+		if _, rollbackErr := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); rollbackErr != nil {
+			return rollbackErr
+		}
+		return err
+	}
+
+	_, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name)
+	return err
+}
+
+// executor returns the transaction carried by ctx, or db when there is none.
+func executor(ctx context.Context, db *sql.DB) boil.ContextExecutor {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+
+	return db
+}
@@ -4,7 +4,9 @@
 package sqlboiler
 
 var TableNames = struct {
-	Employee string
+	Department string
+	Employee   string
 }{
-	Employee: "employee",
+	Department: "department",
+	Employee:   "employee",
 }
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -23,101 +24,66 @@ import (
 
 // Employee is an object representing the database table.
 type Employee struct {
-	ID        int       `boil:"id" json:"id" toml:"id" yaml:"id"`
-	Name      string    `boil:"name" json:"name" toml:"name" yaml:"name"`
-	Position  string    `boil:"position" json:"position" toml:"position" yaml:"position"`
-	Salary    float64   `boil:"salary" json:"salary" toml:"salary" yaml:"salary"`
-	CreatedAt time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	UpdatedAt time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	ID           int       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Name         string    `boil:"name" json:"name" toml:"name" yaml:"name"`
+	Position     string    `boil:"position" json:"position" toml:"position" yaml:"position"`
+	Salary       float64   `boil:"salary" json:"salary" toml:"salary" yaml:"salary"`
+	CreatedAt    time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt    time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	DeletedAt    null.Time `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
+	DepartmentID null.Int  `boil:"department_id" json:"department_id,omitempty" toml:"department_id" yaml:"department_id,omitempty"`
+	ManagerID    null.Int  `boil:"manager_id" json:"manager_id,omitempty" toml:"manager_id" yaml:"manager_id,omitempty"`
 
 	R *employeeR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L employeeL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var EmployeeColumns = struct {
-	ID        string
-	Name      string
-	Position  string
-	Salary    string
-	CreatedAt string
-	UpdatedAt string
+	ID           string
+	Name         string
+	Position     string
+	Salary       string
+	CreatedAt    string
+	UpdatedAt    string
+	DeletedAt    string
+	DepartmentID string
+	ManagerID    string
 }{
-	ID:        "id",
-	Name:      "name",
-	Position:  "position",
-	Salary:    "salary",
-	CreatedAt: "created_at",
-	UpdatedAt: "updated_at",
+	ID:           "id",
+	Name:         "name",
+	Position:     "position",
+	Salary:       "salary",
+	CreatedAt:    "created_at",
+	UpdatedAt:    "updated_at",
+	DeletedAt:    "deleted_at",
+	DepartmentID: "department_id",
+	ManagerID:    "manager_id",
 }
 
 var EmployeeTableColumns = struct {
-	ID        string
-	Name      string
-	Position  string
-	Salary    string
-	CreatedAt string
-	UpdatedAt string
+	ID           string
+	Name         string
+	Position     string
+	Salary       string
+	CreatedAt    string
+	UpdatedAt    string
+	DeletedAt    string
+	DepartmentID string
+	ManagerID    string
 }{
-	ID:        "employee.id",
-	Name:      "employee.name",
-	Position:  "employee.position",
-	Salary:    "employee.salary",
-	CreatedAt: "employee.created_at",
-	UpdatedAt: "employee.updated_at",
+	ID:           "employee.id",
+	Name:         "employee.name",
+	Position:     "employee.position",
+	Salary:       "employee.salary",
+	CreatedAt:    "employee.created_at",
+	UpdatedAt:    "employee.updated_at",
+	DeletedAt:    "employee.deleted_at",
+	DepartmentID: "employee.department_id",
+	ManagerID:    "employee.manager_id",
 }
 
 // Generated where
 
-type whereHelperint struct{ field string }
-
-func (w whereHelperint) EQ(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperint) NEQ(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperint) LT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperint) LTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperint) GT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperint) GTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperint) IN(slice []int) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelperint) NIN(slice []int) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
-type whereHelperstring struct{ field string }
-
-func (w whereHelperstring) EQ(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperstring) NEQ(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperstring) LT(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperstring) LTE(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperstring) GT(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperstring) GTE(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperstring) LIKE(x string) qm.QueryMod   { return qm.Where(w.field+" LIKE ?", x) }
-func (w whereHelperstring) NLIKE(x string) qm.QueryMod  { return qm.Where(w.field+" NOT LIKE ?", x) }
-func (w whereHelperstring) ILIKE(x string) qm.QueryMod  { return qm.Where(w.field+" ILIKE ?", x) }
-func (w whereHelperstring) NILIKE(x string) qm.QueryMod { return qm.Where(w.field+" NOT ILIKE ?", x) }
-func (w whereHelperstring) IN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelperstring) NIN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
 type whereHelperfloat64 struct{ field string }
 
 func (w whereHelperfloat64) EQ(x float64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.EQ, x) }
@@ -147,49 +113,106 @@ func (w whereHelperfloat64) NIN(slice []float64) qm.QueryMod {
 	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
 }
 
-type whereHelpertime_Time struct{ field string }
+type whereHelpernull_Time struct{ field string }
 
-func (w whereHelpertime_Time) EQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.EQ, x)
+func (w whereHelpernull_Time) EQ(x null.Time) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
 }
-func (w whereHelpertime_Time) NEQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.NEQ, x)
+func (w whereHelpernull_Time) NEQ(x null.Time) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
 }
-func (w whereHelpertime_Time) LT(x time.Time) qm.QueryMod {
+func (w whereHelpernull_Time) LT(x null.Time) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.LT, x)
 }
-func (w whereHelpertime_Time) LTE(x time.Time) qm.QueryMod {
+func (w whereHelpernull_Time) LTE(x null.Time) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.LTE, x)
 }
-func (w whereHelpertime_Time) GT(x time.Time) qm.QueryMod {
+func (w whereHelpernull_Time) GT(x null.Time) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GT, x)
 }
-func (w whereHelpertime_Time) GTE(x time.Time) qm.QueryMod {
+func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GTE, x)
 }
 
+func (w whereHelpernull_Time) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Time) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
+type whereHelpernull_Int struct{ field string }
+
+func (w whereHelpernull_Int) EQ(x null.Int) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Int) NEQ(x null.Int) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Int) LT(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Int) LTE(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Int) GT(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Int) GTE(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+func (w whereHelpernull_Int) IN(slice []int) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelpernull_Int) NIN(slice []int) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+func (w whereHelpernull_Int) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Int) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
 var EmployeeWhere = struct {
-	ID        whereHelperint
-	Name      whereHelperstring
-	Position  whereHelperstring
-	Salary    whereHelperfloat64
-	CreatedAt whereHelpertime_Time
-	UpdatedAt whereHelpertime_Time
+	ID           whereHelperint
+	Name         whereHelperstring
+	Position     whereHelperstring
+	Salary       whereHelperfloat64
+	CreatedAt    whereHelpertime_Time
+	UpdatedAt    whereHelpertime_Time
+	DeletedAt    whereHelpernull_Time
+	DepartmentID whereHelpernull_Int
+	ManagerID    whereHelpernull_Int
 }{
-	ID:        whereHelperint{field: "\"employee\".\"id\""},
-	Name:      whereHelperstring{field: "\"employee\".\"name\""},
-	Position:  whereHelperstring{field: "\"employee\".\"position\""},
-	Salary:    whereHelperfloat64{field: "\"employee\".\"salary\""},
-	CreatedAt: whereHelpertime_Time{field: "\"employee\".\"created_at\""},
-	UpdatedAt: whereHelpertime_Time{field: "\"employee\".\"updated_at\""},
+	ID:           whereHelperint{field: "\"employee\".\"id\""},
+	Name:         whereHelperstring{field: "\"employee\".\"name\""},
+	Position:     whereHelperstring{field: "\"employee\".\"position\""},
+	Salary:       whereHelperfloat64{field: "\"employee\".\"salary\""},
+	CreatedAt:    whereHelpertime_Time{field: "\"employee\".\"created_at\""},
+	UpdatedAt:    whereHelpertime_Time{field: "\"employee\".\"updated_at\""},
+	DeletedAt:    whereHelpernull_Time{field: "\"employee\".\"deleted_at\""},
+	DepartmentID: whereHelpernull_Int{field: "\"employee\".\"department_id\""},
+	ManagerID:    whereHelpernull_Int{field: "\"employee\".\"manager_id\""},
 }
 
 // EmployeeRels is where relationship names are stored.
 var EmployeeRels = struct {
-}{}
+	Department       string
+	Manager          string
+	ManagerEmployees string
+}{
+	Department:       "Department",
+	Manager:          "Manager",
+	ManagerEmployees: "ManagerEmployees",
+}
 
 // employeeR is where relationships are stored.
 type employeeR struct {
+	Department       *Department   `boil:"Department" json:"Department" toml:"Department" yaml:"Department"`
+	Manager          *Employee     `boil:"Manager" json:"Manager" toml:"Manager" yaml:"Manager"`
+	ManagerEmployees EmployeeSlice `boil:"ManagerEmployees" json:"ManagerEmployees" toml:"ManagerEmployees" yaml:"ManagerEmployees"`
 }
 
 // NewStruct creates a new relationship struct
@@ -197,13 +220,34 @@ func (*employeeR) NewStruct() *employeeR {
 	return &employeeR{}
 }
 
+func (r *employeeR) GetDepartment() *Department {
+	if r == nil {
+		return nil
+	}
+	return r.Department
+}
+
+func (r *employeeR) GetManager() *Employee {
+	if r == nil {
+		return nil
+	}
+	return r.Manager
+}
+
+func (r *employeeR) GetManagerEmployees() EmployeeSlice {
+	if r == nil {
+		return nil
+	}
+	return r.ManagerEmployees
+}
+
 // employeeL is where Load methods for each relationship are stored.
 type employeeL struct{}
 
 var (
-	employeeAllColumns            = []string{"id", "name", "position", "salary", "created_at", "updated_at"}
+	employeeAllColumns            = []string{"id", "name", "position", "salary", "created_at", "updated_at", "deleted_at", "department_id", "manager_id"}
 	employeeColumnsWithoutDefault = []string{"name", "position", "salary"}
-	employeeColumnsWithDefault    = []string{"id", "created_at", "updated_at"}
+	employeeColumnsWithDefault    = []string{"id", "created_at", "updated_at", "deleted_at", "department_id", "manager_id"}
 	employeePrimaryKeyColumns     = []string{"id"}
 	employeeGeneratedColumns      = []string{}
 )
@@ -513,6 +557,690 @@ func (q employeeQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (b
 	return count > 0, nil
 }
 
+// Department pointed to by the foreign key.
+func (o *Employee) Department(mods ...qm.QueryMod) departmentQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.DepartmentID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Departments(queryMods...)
+}
+
+// Manager pointed to by the foreign key.
+func (o *Employee) Manager(mods ...qm.QueryMod) employeeQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.ManagerID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Employees(queryMods...)
+}
+
+// ManagerEmployees retrieves all the employee's Employees with an executor via manager_id column.
+func (o *Employee) ManagerEmployees(mods ...qm.QueryMod) employeeQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"employee\".\"manager_id\"=?", o.ID),
+	)
+
+	return Employees(queryMods...)
+}
+
+// LoadDepartment allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (employeeL) LoadDepartment(ctx context.Context, e boil.ContextExecutor, singular bool, maybeEmployee interface{}, mods queries.Applicator) error {
+	var slice []*Employee
+	var object *Employee
+
+	if singular {
+		var ok bool
+		object, ok = maybeEmployee.(*Employee)
+		if !ok {
+			object = new(Employee)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeEmployee)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeEmployee))
+			}
+		}
+	} else {
+		s, ok := maybeEmployee.(*[]*Employee)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeEmployee)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeEmployee))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &employeeR{}
+		}
+		if !queries.IsNil(object.DepartmentID) {
+			args[object.DepartmentID] = struct{}{}
+		}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &employeeR{}
+			}
+
+			if !queries.IsNil(obj.DepartmentID) {
+				args[obj.DepartmentID] = struct{}{}
+			}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`department`),
+		qm.WhereIn(`department.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Department")
+	}
+
+	var resultSlice []*Department
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Department")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for department")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for department")
+	}
+
+	if len(departmentAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Department = foreign
+		if foreign.R == nil {
+			foreign.R = &departmentR{}
+		}
+		foreign.R.Employees = append(foreign.R.Employees, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if queries.Equal(local.DepartmentID, foreign.ID) {
+				local.R.Department = foreign
+				if foreign.R == nil {
+					foreign.R = &departmentR{}
+				}
+				foreign.R.Employees = append(foreign.R.Employees, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadManager allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (employeeL) LoadManager(ctx context.Context, e boil.ContextExecutor, singular bool, maybeEmployee interface{}, mods queries.Applicator) error {
+	var slice []*Employee
+	var object *Employee
+
+	if singular {
+		var ok bool
+		object, ok = maybeEmployee.(*Employee)
+		if !ok {
+			object = new(Employee)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeEmployee)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeEmployee))
+			}
+		}
+	} else {
+		s, ok := maybeEmployee.(*[]*Employee)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeEmployee)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeEmployee))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &employeeR{}
+		}
+		if !queries.IsNil(object.ManagerID) {
+			args[object.ManagerID] = struct{}{}
+		}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &employeeR{}
+			}
+
+			if !queries.IsNil(obj.ManagerID) {
+				args[obj.ManagerID] = struct{}{}
+			}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`employee`),
+		qm.WhereIn(`employee.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Employee")
+	}
+
+	var resultSlice []*Employee
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Employee")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for employee")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for employee")
+	}
+
+	if len(employeeAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Manager = foreign
+		if foreign.R == nil {
+			foreign.R = &employeeR{}
+		}
+		foreign.R.ManagerEmployees = append(foreign.R.ManagerEmployees, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if queries.Equal(local.ManagerID, foreign.ID) {
+				local.R.Manager = foreign
+				if foreign.R == nil {
+					foreign.R = &employeeR{}
+				}
+				foreign.R.ManagerEmployees = append(foreign.R.ManagerEmployees, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadManagerEmployees allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (employeeL) LoadManagerEmployees(ctx context.Context, e boil.ContextExecutor, singular bool, maybeEmployee interface{}, mods queries.Applicator) error {
+	var slice []*Employee
+	var object *Employee
+
+	if singular {
+		var ok bool
+		object, ok = maybeEmployee.(*Employee)
+		if !ok {
+			object = new(Employee)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeEmployee)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeEmployee))
+			}
+		}
+	} else {
+		s, ok := maybeEmployee.(*[]*Employee)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeEmployee)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeEmployee))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &employeeR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &employeeR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`employee`),
+		qm.WhereIn(`employee.manager_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load employee")
+	}
+
+	var resultSlice []*Employee
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice employee")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on employee")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for employee")
+	}
+
+	if len(employeeAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.ManagerEmployees = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &employeeR{}
+			}
+			foreign.R.Manager = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.ManagerID) {
+				local.R.ManagerEmployees = append(local.R.ManagerEmployees, foreign)
+				if foreign.R == nil {
+					foreign.R = &employeeR{}
+				}
+				foreign.R.Manager = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetDepartment of the employee to the related item.
+// Sets o.R.Department to related.
+// Adds o to related.R.Employees.
+func (o *Employee) SetDepartment(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Department) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"employee\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"department_id"}),
+		strmangle.WhereClause("\"", "\"", 2, employeePrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.DepartmentID, related.ID)
+	if o.R == nil {
+		o.R = &employeeR{
+			Department: related,
+		}
+	} else {
+		o.R.Department = related
+	}
+
+	if related.R == nil {
+		related.R = &departmentR{
+			Employees: EmployeeSlice{o},
+		}
+	} else {
+		related.R.Employees = append(related.R.Employees, o)
+	}
+
+	return nil
+}
+
+// RemoveDepartment relationship.
+// Sets o.R.Department to nil.
+// Removes o from all passed in related items' relationships struct.
+func (o *Employee) RemoveDepartment(ctx context.Context, exec boil.ContextExecutor, related *Department) error {
+	var err error
+
+	queries.SetScanner(&o.DepartmentID, nil)
+	if _, err = o.Update(ctx, exec, boil.Whitelist("department_id")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.Department = nil
+	}
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.Employees {
+		if queries.Equal(o.DepartmentID, ri.DepartmentID) {
+			continue
+		}
+
+		ln := len(related.R.Employees)
+		if ln > 1 && i < ln-1 {
+			related.R.Employees[i] = related.R.Employees[ln-1]
+		}
+		related.R.Employees = related.R.Employees[:ln-1]
+		break
+	}
+	return nil
+}
+
+// SetManager of the employee to the related item.
+// Sets o.R.Manager to related.
+// Adds o to related.R.ManagerEmployees.
+func (o *Employee) SetManager(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Employee) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"employee\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"manager_id"}),
+		strmangle.WhereClause("\"", "\"", 2, employeePrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.ManagerID, related.ID)
+	if o.R == nil {
+		o.R = &employeeR{
+			Manager: related,
+		}
+	} else {
+		o.R.Manager = related
+	}
+
+	if related.R == nil {
+		related.R = &employeeR{
+			ManagerEmployees: EmployeeSlice{o},
+		}
+	} else {
+		related.R.ManagerEmployees = append(related.R.ManagerEmployees, o)
+	}
+
+	return nil
+}
+
+// RemoveManager relationship.
+// Sets o.R.Manager to nil.
+// Removes o from all passed in related items' relationships struct.
+func (o *Employee) RemoveManager(ctx context.Context, exec boil.ContextExecutor, related *Employee) error {
+	var err error
+
+	queries.SetScanner(&o.ManagerID, nil)
+	if _, err = o.Update(ctx, exec, boil.Whitelist("manager_id")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.Manager = nil
+	}
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.ManagerEmployees {
+		if queries.Equal(o.ManagerID, ri.ManagerID) {
+			continue
+		}
+
+		ln := len(related.R.ManagerEmployees)
+		if ln > 1 && i < ln-1 {
+			related.R.ManagerEmployees[i] = related.R.ManagerEmployees[ln-1]
+		}
+		related.R.ManagerEmployees = related.R.ManagerEmployees[:ln-1]
+		break
+	}
+	return nil
+}
+
+// AddManagerEmployees adds the given related objects to the existing relationships
+// of the employee, optionally inserting them as new records.
+// Appends related to o.R.ManagerEmployees.
+// Sets related.R.Manager appropriately.
+func (o *Employee) AddManagerEmployees(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Employee) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.ManagerID, o.ID)
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"employee\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"manager_id"}),
+				strmangle.WhereClause("\"", "\"", 2, employeePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.ManagerID, o.ID)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &employeeR{
+			ManagerEmployees: related,
+		}
+	} else {
+		o.R.ManagerEmployees = append(o.R.ManagerEmployees, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &employeeR{
+				Manager: o,
+			}
+		} else {
+			rel.R.Manager = o
+		}
+	}
+	return nil
+}
+
+// SetManagerEmployees removes all previously related items of the
+// employee replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.Manager's ManagerEmployees accordingly.
+// Replaces o.R.ManagerEmployees with related.
+// Sets related.R.Manager's ManagerEmployees accordingly.
+func (o *Employee) SetManagerEmployees(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Employee) error {
+	query := "update \"employee\" set \"manager_id\" = null where \"manager_id\" = $1"
+	values := []interface{}{o.ID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.ManagerEmployees {
+			queries.SetScanner(&rel.ManagerID, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.Manager = nil
+		}
+		o.R.ManagerEmployees = nil
+	}
+
+	return o.AddManagerEmployees(ctx, exec, insert, related...)
+}
+
+// RemoveManagerEmployees relationships from objects passed in.
+// Removes related items from R.ManagerEmployees (uses pointer comparison, removal does not keep order)
+// Sets related.R.Manager.
+func (o *Employee) RemoveManagerEmployees(ctx context.Context, exec boil.ContextExecutor, related ...*Employee) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.ManagerID, nil)
+		if rel.R != nil {
+			rel.R.Manager = nil
+		}
+		if _, err = rel.Update(ctx, exec, boil.Whitelist("manager_id")); err != nil {
+			return err
+		}
+	}
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.ManagerEmployees {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.ManagerEmployees)
+			if ln > 1 && i < ln-1 {
+				o.R.ManagerEmployees[i] = o.R.ManagerEmployees[ln-1]
+			}
+			o.R.ManagerEmployees = o.R.ManagerEmployees[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
 // Employees retrieves all the records using an executor.
 func Employees(mods ...qm.QueryMod) employeeQuery {
 	mods = append(mods, qm.From("\"employee\""))
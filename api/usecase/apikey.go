@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"employee-management/domain/apperror"
+	"employee-management/domain/dto"
+	"employee-management/domain/interfaces"
+	"employee-management/utils/requestctx"
+	"employee-management/utils/validation"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// apiKeyBytes is the entropy of a key. Keys this long need no slow
+	// hash: SHA-256 is enough to keep a leaked table from revealing them.
+	apiKeyBytes = 32
+	// apiKeyPrefixLength is the number of characters kept for listings.
+	apiKeyPrefixLength = len(dto.APIKeyPrefix) + 8
+)
+
+type apiKeyUsecase struct {
+	repo       interfaces.APIKeyRepository
+	transactor interfaces.Transactor
+	opts       Options
+	now        func() time.Time
+}
+
+// NewAPIKeyUsecase returns an APIKeyUsecase. Only the page sizes of opts
+// apply to API keys.
+func NewAPIKeyUsecase(repo interfaces.APIKeyRepository, transactor interfaces.Transactor, opts Options) interfaces.APIKeyUsecase {
+	return &apiKeyUsecase{
+		repo:       repo,
+		transactor: transactor,
+		opts:       opts.withDefaults(),
+		now:        time.Now,
+	}
+}
+
+// CreateAPIKey issues a key granting the requested scopes. Only an
+// authenticated caller may create keys, and it cannot grant scopes it does
+// not hold itself.
+func (uc *apiKeyUsecase) CreateAPIKey(ctx context.Context, request *dto.CreateAPIKeyRequest) (*dto.CreatedAPIKey, error) {
+	principal, authenticated := requestctx.Principal(ctx)
+	if !authenticated {
+		return nil, apperror.New(apperror.KindUnauthenticated, "authentication_required",
+			"API keys can only be created by an authenticated caller")
+	}
+	if err := validation.Struct(request); err != nil {
+		return nil, err
+	}
+
+	var fields []apperror.FieldError
+	for i, scope := range request.Scopes {
+		pointer := "/scopes/" + strconv.Itoa(i)
+		switch {
+		case !slices.Contains(dto.Permissions, scope):
+			fields = append(fields, apperror.FieldError{
+				Pointer: pointer,
+				Code:    "field_invalid",
+				Message: fmt.Sprintf("scope %q is not one of %s", scope, strings.Join(dto.Permissions, ", ")),
+			})
+		case !principal.Can(scope):
+			fields = append(fields, apperror.FieldError{
+				Pointer: pointer,
+				Code:    "field_invalid",
+				Message: fmt.Sprintf("scope %q is not granted to the caller", scope),
+			})
+		}
+	}
+	if request.ExpiresAt != nil && !request.ExpiresAt.After(uc.now()) {
+		fields = append(fields, apperror.FieldError{
+			Pointer: "/expires_at",
+			Code:    "field_out_of_range",
+			Message: "expires_at must be in the future",
+		})
+	}
+	if len(fields) > 0 {
+		return nil, apperror.ValidationFields("validation_failed", "request validation failed", fields)
+	}
+
+	secret, err := newAPIKey()
+	if err != nil {
+		return nil, err
+	}
+	scopes := slices.Clone(request.Scopes)
+	slices.Sort(scopes)
+	key := &dto.APIKey{
+		Name:      request.Name,
+		Prefix:    secret[:apiKeyPrefixLength],
+		Hash:      hashAPIKey(secret),
+		Scopes:    slices.Compact(scopes),
+		ExpiresAt: request.ExpiresAt,
+	}
+	if err := uc.repo.Create(ctx, key); err != nil {
+		return nil, err
+	}
+
+	return &dto.CreatedAPIKey{APIKey: key, Key: secret}, nil
+}
+
+func (uc *apiKeyUsecase) ListAPIKeys(ctx context.Context, request *dto.GetAPIKeys) (*dto.APIKeyPage, error) {
+	if err := validation.Struct(request); err != nil {
+		return nil, err
+	}
+	if fields := uc.opts.offsetBounds(&request.Page, &request.PageSize); len(fields) > 0 {
+		return nil, apperror.ValidationFields("validation_failed", "request validation failed", fields)
+	}
+
+	page := &dto.APIKeyPage{PageInfo: dto.PageInfo{
+		Page:     request.Page,
+		PageSize: request.PageSize,
+	}}
+	err := uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if page.Keys, err = uc.repo.List(ctx, request.PageSize, (request.Page-1)*request.PageSize); err != nil {
+			return err
+		}
+		page.TotalCount, err = uc.repo.Count(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	page.TotalPages = totalPages(page.TotalCount, page.PageSize)
+	return page, nil
+}
+
+func (uc *apiKeyUsecase) RevokeAPIKey(ctx context.Context, apiKeyID int64) error {
+	return uc.repo.Revoke(ctx, apiKeyID)
+}
+
+// AuthenticateAPIKey identifies the client of a key as "api_key:<id>".
+// Failing to record the use of the key does not fail the request.
+func (uc *apiKeyUsecase) AuthenticateAPIKey(ctx context.Context, secret string) (*dto.Principal, error) {
+	invalid := apperror.New(apperror.KindUnauthenticated, "invalid_api_key", "API key is unknown, revoked or expired")
+	if !strings.HasPrefix(secret, dto.APIKeyPrefix) {
+		return nil, invalid
+	}
+
+	key, err := uc.repo.FindByHash(ctx, hashAPIKey(secret))
+	if apperror.KindOf(err) == apperror.KindNotFound {
+		return nil, invalid
+	}
+	if err != nil {
+		return nil, err
+	}
+	now := uc.now()
+	if !key.Active(now) {
+		return nil, invalid
+	}
+	_ = uc.repo.Touch(ctx, key.ID, now)
+
+	return &dto.Principal{
+		Subject:     "api_key:" + strconv.FormatInt(key.ID, 10),
+		Permissions: key.Scopes,
+	}, nil
+}
+
+// newAPIKey returns a random key, e.g. "emk_3q2-7w...".
+func newAPIKey() (string, error) {
+	b := make([]byte, apiKeyBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return dto.APIKeyPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashAPIKey(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
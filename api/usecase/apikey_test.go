@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"context"
+	"employee-management/domain/apperror"
+	"employee-management/domain/dto"
+	"employee-management/utils/requestctx"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errAPIKeyNotFound = apperror.NotFound("api_key_not_found", "api_key not found")
+
+type fakeAPIKeyRepository struct {
+	keys    []*dto.APIKey
+	touched map[int64]time.Time
+}
+
+func newFakeAPIKeyRepository() *fakeAPIKeyRepository {
+	return &fakeAPIKeyRepository{touched: make(map[int64]time.Time)}
+}
+
+func (r *fakeAPIKeyRepository) Create(ctx context.Context, key *dto.APIKey) error {
+	key.ID = int64(len(r.keys) + 1)
+	r.keys = append(r.keys, key)
+	return nil
+}
+
+func (r *fakeAPIKeyRepository) List(ctx context.Context, limit, offset int) ([]*dto.APIKey, error) {
+	keys := make([]*dto.APIKey, 0, limit)
+	for i := len(r.keys) - 1 - offset; i >= 0 && len(keys) < limit; i-- {
+		keys = append(keys, r.keys[i])
+	}
+	return keys, nil
+}
+
+func (r *fakeAPIKeyRepository) Count(ctx context.Context) (int64, error) {
+	return int64(len(r.keys)), nil
+}
+
+func (r *fakeAPIKeyRepository) FindByHash(ctx context.Context, hash string) (*dto.APIKey, error) {
+	for _, key := range r.keys {
+		if key.Hash == hash {
+			return key, nil
+		}
+	}
+	return nil, errAPIKeyNotFound
+}
+
+func (r *fakeAPIKeyRepository) Revoke(ctx context.Context, apiKeyID int64) error {
+	for _, key := range r.keys {
+		if key.ID == apiKeyID {
+			revokedAt := time.Now()
+			key.RevokedAt = &revokedAt
+			return nil
+		}
+	}
+	return errAPIKeyNotFound
+}
+
+func (r *fakeAPIKeyRepository) Touch(ctx context.Context, apiKeyID int64, at time.Time) error {
+	r.touched[apiKeyID] = at
+	return nil
+}
+
+// apiKeyAdmin is a caller allowed to grant every scope.
+func apiKeyAdmin() context.Context {
+	return requestctx.WithPrincipal(context.Background(), &dto.Principal{Subject: "admin", Permissions: dto.Permissions})
+}
+
+func TestCreateAndAuthenticateAPIKey(t *testing.T) {
+	repo := newFakeAPIKeyRepository()
+	uc := NewAPIKeyUsecase(repo, fakeTransactor{}, Options{})
+	ctx := apiKeyAdmin()
+
+	created, err := uc.CreateAPIKey(ctx, &dto.CreateAPIKeyRequest{
+		Name:   " payroll ",
+		Scopes: []string{dto.PermissionSalaryRead, dto.PermissionEmployeeRead, dto.PermissionSalaryRead},
+	})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(created.Key, dto.APIKeyPrefix))
+	assert.Equal(t, created.Key[:len(created.Prefix)], created.Prefix)
+	assert.Len(t, created.Hash, 64)
+	assert.Equal(t, "payroll", created.Name)
+	assert.Equal(t, []string{dto.PermissionEmployeeRead, dto.PermissionSalaryRead}, created.Scopes)
+
+	principal, err := uc.AuthenticateAPIKey(ctx, created.Key)
+	require.NoError(t, err)
+	assert.Equal(t, "api_key:1", principal.Subject)
+	assert.True(t, principal.Can(dto.PermissionSalaryRead))
+	assert.False(t, principal.Can(dto.PermissionEmployeeWrite))
+	assert.Contains(t, repo.touched, int64(1))
+
+	require.NoError(t, uc.RevokeAPIKey(ctx, 1))
+	_, err = uc.AuthenticateAPIKey(ctx, created.Key)
+	assert.Equal(t, apperror.KindUnauthenticated, apperror.KindOf(err))
+}
+
+func TestAuthenticateAPIKeyRejects(t *testing.T) {
+	repo := newFakeAPIKeyRepository()
+	uc := NewAPIKeyUsecase(repo, fakeTransactor{}, Options{})
+	ctx := apiKeyAdmin()
+	expiresAt := time.Now().Add(time.Hour)
+	created, err := uc.CreateAPIKey(ctx, &dto.CreateAPIKeyRequest{Name: "badges", Scopes: []string{dto.PermissionEmployeeRead}, ExpiresAt: &expiresAt})
+	require.NoError(t, err)
+	uc.(*apiKeyUsecase).now = func() time.Time { return expiresAt }
+
+	for _, key := range []string{created.Key, dto.APIKeyPrefix + "unknown", "not-a-key"} {
+		_, err := uc.AuthenticateAPIKey(ctx, key)
+
+		appErr, ok := apperror.As(err)
+		require.True(t, ok)
+		assert.Equal(t, apperror.KindUnauthenticated, appErr.Kind)
+		assert.Equal(t, "invalid_api_key", appErr.Code)
+	}
+	assert.Empty(t, repo.touched)
+}
+
+func TestCreateAPIKeyValidates(t *testing.T) {
+	uc := NewAPIKeyUsecase(newFakeAPIKeyRepository(), fakeTransactor{}, Options{})
+	hr := requestctx.WithPrincipal(context.Background(), &dto.Principal{
+		Subject:     "alice",
+		Permissions: []string{dto.PermissionEmployeeRead, dto.PermissionAPIKeyManage},
+	})
+	expired := time.Now().Add(-time.Hour)
+
+	_, err := uc.CreateAPIKey(hr, &dto.CreateAPIKeyRequest{
+		Name:      "payroll",
+		Scopes:    []string{dto.PermissionEmployeeRead, "salary:peek", dto.PermissionSalaryRead},
+		ExpiresAt: &expired,
+	})
+
+	appErr, ok := apperror.As(err)
+	require.True(t, ok)
+	assert.Equal(t, apperror.KindValidation, appErr.Kind)
+	var pointers []string
+	for _, field := range appErr.Fields {
+		pointers = append(pointers, field.Pointer)
+	}
+	assert.Equal(t, []string{"/scopes/1", "/scopes/2", "/expires_at"}, pointers)
+}
+
+func TestCreateAPIKeyRequiresAuthentication(t *testing.T) {
+	repo := newFakeAPIKeyRepository()
+	uc := NewAPIKeyUsecase(repo, fakeTransactor{}, Options{})
+
+	_, err := uc.CreateAPIKey(context.Background(), &dto.CreateAPIKeyRequest{
+		Name:   "payroll",
+		Scopes: []string{dto.PermissionAPIKeyManage},
+	})
+
+	appErr, ok := apperror.As(err)
+	require.True(t, ok)
+	assert.Equal(t, apperror.KindUnauthenticated, appErr.Kind)
+	assert.Equal(t, "authentication_required", appErr.Code)
+	assert.Empty(t, repo.keys)
+}
+
+func TestListAPIKeys(t *testing.T) {
+	repo := newFakeAPIKeyRepository()
+	uc := NewAPIKeyUsecase(repo, fakeTransactor{}, Options{})
+	for _, name := range []string{"payroll", "reports", "backup"} {
+		_, err := uc.CreateAPIKey(apiKeyAdmin(), &dto.CreateAPIKeyRequest{Name: name, Scopes: []string{dto.PermissionEmployeeRead}})
+		require.NoError(t, err)
+	}
+
+	page, err := uc.ListAPIKeys(context.Background(), &dto.GetAPIKeys{Page: 2, PageSize: 2})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), page.TotalCount)
+	assert.Equal(t, 2, page.TotalPages)
+	require.Len(t, page.Keys, 1)
+	assert.Equal(t, "payroll", page.Keys[0].Name)
+
+	_, err = uc.ListAPIKeys(context.Background(), &dto.GetAPIKeys{PageSize: maxPageSize + 1})
+
+	assert.Equal(t, apperror.KindValidation, apperror.KindOf(err))
+}
@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"employee-management/domain/apperror"
+	"employee-management/domain/dto"
+	"employee-management/utils/validation"
+	"encoding/json"
+	"errors"
+)
+
+// errBatchRolledBack ends the transaction of an atomic batch with a failed
+// operation without committing it.
+var errBatchRolledBack = errors.New("batch rolled back")
+
+// BatchEmployees runs the operations of a batch in order in one
+// transaction. Every operation runs like the usecase method of its kind,
+// in a savepoint of its own, so a failed operation leaves no trace. An
+// atomic batch stops at the first failed operation and is rolled back;
+// other batches are committed with the operations that succeeded.
+func (uc *employeeUsecase) BatchEmployees(ctx context.Context, request *dto.BatchRequest) (*dto.BatchResult, error) {
+	if err := validation.Struct(request); err != nil {
+		return nil, err
+	}
+
+	result := &dto.BatchResult{
+		Atomic:     request.Atomic == nil || *request.Atomic,
+		Total:      len(request.Operations),
+		Operations: make([]*dto.BatchOperationResult, 0, len(request.Operations)),
+	}
+	err := uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		for i, operation := range request.Operations {
+			opResult := &dto.BatchOperationResult{Index: i, Op: operation.Op, Status: dto.BatchOperationSucceeded}
+			err := uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+				return uc.batchOperation(ctx, operation, opResult)
+			})
+			if appErr, ok := apperror.As(err); err != nil && (!ok || appErr.Kind == apperror.KindInternal) {
+				return err
+			}
+			result.Operations = append(result.Operations, opResult)
+			if err != nil {
+				opResult.Status, opResult.Err = dto.BatchOperationFailed, err
+				opResult.Employee = nil
+				result.Failed++
+				if result.Atomic {
+					return errBatchRolledBack
+				}
+			}
+		}
+		return nil
+	})
+	switch {
+	case errors.Is(err, errBatchRolledBack):
+	case err != nil:
+		return nil, err
+	default:
+		result.Committed = true
+	}
+
+	for _, opResult := range result.Operations {
+		switch {
+		case opResult.Status != dto.BatchOperationSucceeded:
+		case result.Committed:
+			result.Succeeded++
+		default:
+			opResult.Status, opResult.Employee = dto.BatchOperationRolledBack, nil
+			if opResult.Op == dto.BatchCreate {
+				opResult.ID = nil
+			}
+		}
+	}
+	for i := len(result.Operations); i < result.Total; i++ {
+		operation := request.Operations[i]
+		opResult := &dto.BatchOperationResult{Index: i, Op: operation.Op, Status: dto.BatchOperationSkipped}
+		if operation.ID != 0 {
+			opResult.ID = &operation.ID
+		}
+		result.Operations = append(result.Operations, opResult)
+	}
+
+	return result, nil
+}
+
+// batchOperation runs one operation and records its outcome in result.
+func (uc *employeeUsecase) batchOperation(ctx context.Context, operation *dto.BatchOperation, result *dto.BatchOperationResult) error {
+	switch operation.Op {
+	case dto.BatchCreate:
+		request := new(dto.EmployeeCreateRequest)
+		if err := decodeBatchBody(operation.Body, request); err != nil {
+			return err
+		}
+		response, err := uc.CreateEmployee(ctx, request)
+		if err != nil {
+			return err
+		}
+		result.ID = &response.Id
+		return nil
+	case dto.BatchUpdate:
+		result.ID = &operation.ID
+		request := new(dto.UpdateEmployeeBodyRequest)
+		if err := decodeBatchBody(operation.Body, request); err != nil {
+			return err
+		}
+		employee, err := uc.UpdateEmployee(ctx, operation.ID, request, operation.Precondition)
+		if err != nil {
+			return err
+		}
+		result.Employee = employee
+		return nil
+	default:
+		result.ID = &operation.ID
+		return uc.DeleteEmployee(ctx, operation.ID, operation.Precondition)
+	}
+}
+
+// decodeBatchBody decodes the body of an operation like the JSON binding
+// of the endpoint it stands in for.
+func decodeBatchBody(body json.RawMessage, v interface{}) error {
+	if err := json.Unmarshal(body, v); err != nil {
+		return apperror.Wrap(err, apperror.KindInvalidInput, "invalid_request", err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"employee-management/domain/apperror"
+	"employee-management/domain/dto"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchEmployeesAtomic(t *testing.T) {
+	repo := newFakeEmployeeRepository(&dto.Employee{ID: 1, Name: "John Doe", Position: "Developer", Salary: 60000})
+	uc := newImportUsecase(repo, Options{})
+
+	result, err := uc.BatchEmployees(context.Background(), &dto.BatchRequest{Operations: []*dto.BatchOperation{
+		{Op: dto.BatchCreate, Body: json.RawMessage(`{"name":"Jane Smith","position":"Manager","salary":80000}`)},
+		{Op: dto.BatchUpdate, ID: 1, Body: json.RawMessage(`{"name":"John Doe","position":"Lead","salary":70000}`)},
+		{Op: dto.BatchDelete, ID: 9},
+		{Op: dto.BatchDelete, ID: 1},
+	}})
+
+	assert.NoError(t, err)
+	assert.True(t, result.Atomic)
+	assert.False(t, result.Committed)
+	assert.Equal(t, 4, result.Total)
+	assert.Equal(t, 0, result.Succeeded)
+	assert.Equal(t, 1, result.Failed)
+
+	statuses := make([]string, 0, len(result.Operations))
+	for _, operation := range result.Operations {
+		statuses = append(statuses, operation.Status)
+	}
+	assert.Equal(t, []string{dto.BatchOperationRolledBack, dto.BatchOperationRolledBack, dto.BatchOperationFailed, dto.BatchOperationSkipped}, statuses)
+	assert.Nil(t, result.Operations[0].ID)
+	assert.Nil(t, result.Operations[1].Employee)
+	assert.Equal(t, apperror.KindNotFound, apperror.KindOf(result.Operations[2].Err))
+	assert.Equal(t, 3, result.Operations[3].Index)
+
+	assert.Len(t, repo.employees, 1)
+	assert.Equal(t, "Developer", repo.employees[1].Position)
+	assert.Nil(t, repo.employees[1].DeletedAt)
+}
+
+func TestBatchEmployeesNotAtomic(t *testing.T) {
+	repo := newFakeEmployeeRepository(&dto.Employee{ID: 1, Name: "John Doe", Position: "Developer", Salary: 60000})
+	uc := newImportUsecase(repo, Options{})
+	atomic := false
+
+	result, err := uc.BatchEmployees(context.Background(), &dto.BatchRequest{Atomic: &atomic, Operations: []*dto.BatchOperation{
+		{Op: dto.BatchCreate, Body: json.RawMessage(`{"name":"Jane Smith","position":"Manager","salary":0}`)},
+		{Op: dto.BatchCreate, Body: json.RawMessage(`{"name":"Jim Doe","position":"QA","salary":50000}`)},
+		{Op: dto.BatchUpdate, ID: 1, Body: json.RawMessage(`{"name":`)},
+		{Op: dto.BatchDelete, ID: 1},
+	}})
+
+	assert.NoError(t, err)
+	assert.True(t, result.Committed)
+	assert.Equal(t, 2, result.Succeeded)
+	assert.Equal(t, 2, result.Failed)
+
+	appErr, _ := apperror.As(result.Operations[0].Err)
+	assert.Equal(t, apperror.KindValidation, appErr.Kind)
+	assert.Equal(t, "/salary", appErr.Fields[0].Pointer)
+	assert.Equal(t, dto.BatchOperationSucceeded, result.Operations[1].Status)
+	assert.Equal(t, 2, *result.Operations[1].ID)
+	assert.Equal(t, apperror.KindInvalidInput, apperror.KindOf(result.Operations[2].Err))
+	assert.Equal(t, dto.BatchOperationSucceeded, result.Operations[3].Status)
+
+	assert.Equal(t, "Jim Doe", repo.employees[2].Name)
+	assert.NotNil(t, repo.employees[1].DeletedAt)
+}
+
+func TestBatchEmployeesValidates(t *testing.T) {
+	uc := newImportUsecase(newFakeEmployeeRepository(), Options{})
+
+	_, err := uc.BatchEmployees(context.Background(), &dto.BatchRequest{Operations: []*dto.BatchOperation{
+		{Op: dto.BatchCreate, Body: json.RawMessage(`{}`)},
+		{Op: dto.BatchUpdate},
+		{Op: "upsert", ID: 1},
+	}})
+
+	appErr, ok := apperror.As(err)
+	assert.True(t, ok)
+	assert.Equal(t, apperror.KindValidation, appErr.Kind)
+	var pointers []string
+	for _, field := range appErr.Fields {
+		pointers = append(pointers, field.Pointer)
+	}
+	assert.Equal(t, []string{"/operations/1/id", "/operations/1/body", "/operations/2/op", "/operations/2/body"}, pointers)
+}
@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"employee-management/domain/apperror"
+	"employee-management/domain/dto"
+	"employee-management/utils/validation"
+	"time"
+)
+
+// today is the current UTC calendar day, the day compensation records
+// take effect on.
+func today() time.Time {
+	year, month, day := time.Now().UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// ScheduleCompensation adds a salary change from a day on, today or later.
+// The change lasts until the next scheduled change, if there is one. A
+// change taking effect today updates the employee as well.
+func (uc *employeeUsecase) ScheduleCompensation(ctx context.Context, employeeID int, request *dto.ScheduleCompensationRequest) (*dto.Compensation, error) {
+	if err := validation.Struct(request); err != nil {
+		return nil, err
+	}
+	effectiveFrom, err := time.Parse(dto.DateLayout, request.EffectiveFrom)
+	if err != nil {
+		return nil, err
+	}
+	if effectiveFrom.Before(today()) {
+		return nil, apperror.ValidationFields("validation_failed", "request validation failed", []apperror.FieldError{{
+			Pointer: "/effective_from",
+			Code:    "field_out_of_range",
+			Message: "effective_from must not be in the past",
+		}})
+	}
+
+	compensation := &dto.Compensation{
+		EmployeeID:    employeeID,
+		Amount:        request.Amount,
+		Currency:      request.Currency,
+		Reason:        request.Reason,
+		EffectiveFrom: effectiveFrom,
+	}
+	err = uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// the lock serializes changes to the timeline of the employee
+		employee, err := uc.repo.LockByID(ctx, employeeID)
+		if err != nil {
+			return err
+		}
+		if err := uc.insertCompensation(ctx, compensation, false); err != nil {
+			return err
+		}
+		if !compensation.ActiveOn(today()) {
+			return nil
+		}
+		// the salary changes at once: updating the employee stamps its
+		// version and audits the change
+		employee.Salary = compensation.Amount
+		return uc.repo.Update(ctx, employee)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	compensation.Active = compensation.ActiveOn(today())
+	return compensation, nil
+}
+
+// GetCompensationTimeline lists the compensation records of an employee,
+// oldest first.
+func (uc *employeeUsecase) GetCompensationTimeline(ctx context.Context, employeeID int) ([]*dto.Compensation, error) {
+	var timeline []*dto.Compensation
+	err := uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := uc.repo.FindByID(ctx, employeeID); err != nil {
+			return err
+		}
+		var err error
+		timeline, err = uc.compensations.List(ctx, employeeID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	day := today()
+	for _, compensation := range timeline {
+		compensation.Active = compensation.ActiveOn(day)
+	}
+	return timeline, nil
+}
+
+// insertCompensation fits compensation into the timeline of its employee:
+// the record before it now ends where it starts, and it ends where the
+// record after it starts. A record starting on the same day is a conflict,
+// or is overwritten when replace is set. It must run in a transaction that
+// holds the lock of the employee.
+func (uc *employeeUsecase) insertCompensation(ctx context.Context, compensation *dto.Compensation, replace bool) error {
+	timeline, err := uc.compensations.List(ctx, compensation.EmployeeID)
+	if err != nil {
+		return err
+	}
+
+	var previous, next *dto.Compensation
+	for _, existing := range timeline {
+		switch {
+		case existing.EffectiveFrom.Equal(compensation.EffectiveFrom):
+			if !replace {
+				return apperror.Conflict("compensation_already_exists", "a compensation change is already scheduled for that day")
+			}
+			existing.Amount = compensation.Amount
+			existing.Reason = compensation.Reason
+			if compensation.Currency != "" {
+				existing.Currency = compensation.Currency
+			}
+			*compensation = *existing
+			return uc.compensations.Update(ctx, compensation)
+		case existing.EffectiveFrom.Before(compensation.EffectiveFrom):
+			previous = existing
+		case next == nil:
+			next = existing
+		}
+	}
+
+	if compensation.Currency == "" {
+		compensation.Currency = uc.opts.DefaultCurrency
+		if previous != nil {
+			compensation.Currency = previous.Currency
+		}
+	}
+	if next != nil {
+		compensation.EffectiveTo = &next.EffectiveFrom
+	}
+	if previous != nil {
+		previous.EffectiveTo = &compensation.EffectiveFrom
+		if err := uc.compensations.Update(ctx, previous); err != nil {
+			return err
+		}
+	}
+
+	return uc.compensations.Create(ctx, compensation)
+}
@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"employee-management/domain/apperror"
+	"employee-management/domain/dto"
+	"encoding/base64"
+	"encoding/json"
+)
+
+// employeeCursor is the decoded form of the opaque keyset cursor. After
+// holds the sort fields and id of the last employee of the previous page,
+// keyed by their JSON names.
+type employeeCursor struct {
+	Sort  string                     `json:"sort"`
+	After map[string]json.RawMessage `json:"after"`
+}
+
+// encodeCursor returns the cursor continuing after last.
+func encodeCursor(sort []dto.SortField, last *dto.Employee) (string, error) {
+	data, err := json.Marshal(last)
+	if err != nil {
+		return "", err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return "", err
+	}
+
+	cursor := employeeCursor{
+		Sort:  formatSort(sort),
+		After: map[string]json.RawMessage{"id": all["id"]},
+	}
+	for _, field := range sort {
+		cursor.After[field.Field] = all[field.Field]
+	}
+
+	data, err = json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeCursor returns the sort order and the position encoded in cursor.
+func decodeCursor(cursor string) ([]dto.SortField, *dto.Employee, *apperror.FieldError) {
+	invalid := &apperror.FieldError{
+		Pointer: "/cursor",
+		Code:    "field_invalid",
+		Message: "cursor is invalid",
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, nil, invalid
+	}
+	var decoded employeeCursor
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, nil, invalid
+	}
+
+	sort, sortErr := parseSort(decoded.Sort)
+	if sortErr != nil {
+		return nil, nil, invalid
+	}
+	if _, ok := decoded.After["id"]; !ok {
+		return nil, nil, invalid
+	}
+	for _, field := range sort {
+		if _, ok := decoded.After[field.Field]; !ok {
+			return nil, nil, invalid
+		}
+	}
+
+	data, err = json.Marshal(decoded.After)
+	if err != nil {
+		return nil, nil, invalid
+	}
+	after := new(dto.Employee)
+	if err := json.Unmarshal(data, after); err != nil {
+		return nil, nil, invalid
+	}
+
+	return sort, after, nil
+}
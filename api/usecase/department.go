@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"employee-management/domain/apperror"
+	"employee-management/domain/dto"
+	"employee-management/domain/interfaces"
+	"employee-management/utils/validation"
+)
+
+type departmentUsecase struct {
+	repo       interfaces.DepartmentRepository
+	employees  interfaces.EmployeeRepository
+	transactor interfaces.Transactor
+	opts       Options
+}
+
+// NewDepartmentUsecase returns a DepartmentUsecase. Only the page sizes of
+// opts apply to departments.
+func NewDepartmentUsecase(repo interfaces.DepartmentRepository, employees interfaces.EmployeeRepository, transactor interfaces.Transactor, opts Options) interfaces.DepartmentUsecase {
+	return &departmentUsecase{
+		repo:       repo,
+		employees:  employees,
+		transactor: transactor,
+		opts:       opts.withDefaults(),
+	}
+}
+
+func (uc *departmentUsecase) GetDepartmentByID(ctx context.Context, departmentID int) (*dto.Department, error) {
+	return uc.repo.FindByID(ctx, departmentID)
+}
+
+func (uc *departmentUsecase) GetAllDepartments(ctx context.Context, request *dto.GetDepartments) (*dto.DepartmentPage, error) {
+	if err := validation.Struct(request); err != nil {
+		return nil, err
+	}
+	if fields := uc.opts.offsetBounds(&request.Page, &request.PageSize); len(fields) > 0 {
+		return nil, apperror.ValidationFields("validation_failed", "request validation failed", fields)
+	}
+
+	page := &dto.DepartmentPage{PageInfo: dto.PageInfo{
+		Page:     request.Page,
+		PageSize: request.PageSize,
+	}}
+	err := uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if page.Departments, err = uc.repo.List(ctx, request.PageSize, (request.Page-1)*request.PageSize); err != nil {
+			return err
+		}
+		page.TotalCount, err = uc.repo.Count(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	page.TotalPages = totalPages(page.TotalCount, page.PageSize)
+	return page, nil
+}
+
+func (uc *departmentUsecase) CreateDepartment(ctx context.Context, request *dto.DepartmentRequest) (*dto.Department, error) {
+	if err := validation.Struct(request); err != nil {
+		return nil, err
+	}
+
+	department := &dto.Department{Name: request.Name}
+	if err := uc.repo.Create(ctx, department); err != nil {
+		return nil, err
+	}
+
+	return department, nil
+}
+
+func (uc *departmentUsecase) UpdateDepartment(ctx context.Context, departmentID int, request *dto.DepartmentRequest) (*dto.Department, error) {
+	if err := validation.Struct(request); err != nil {
+		return nil, err
+	}
+
+	var department *dto.Department
+	err := uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if department, err = uc.repo.FindByID(ctx, departmentID); err != nil {
+			return err
+		}
+		department.Name = request.Name
+		return uc.repo.Update(ctx, department)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return department, nil
+}
+
+// DeleteDepartment removes a department that no employee, deleted or not,
+// belongs to.
+func (uc *departmentUsecase) DeleteDepartment(ctx context.Context, departmentID int) error {
+	return uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := uc.repo.FindByID(ctx, departmentID); err != nil {
+			return err
+		}
+
+		count, err := uc.employees.Count(ctx, dto.EmployeeFilter{
+			Deleted:       dto.DeletedInclude,
+			DepartmentIDs: []int{departmentID},
+		})
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return apperror.Conflict("department_not_empty", "department still has employees")
+		}
+
+		return uc.repo.Delete(ctx, departmentID)
+	})
+}
@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+	"employee-management/domain/apperror"
+	"employee-management/domain/dto"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errDepartmentNotFound = apperror.NotFound("department_not_found", "department not found")
+
+// fakeDepartmentRepository is an in-memory interfaces.DepartmentRepository.
+type fakeDepartmentRepository struct {
+	departments map[int]*dto.Department
+	nextID      int
+}
+
+func newFakeDepartmentRepository(departments ...*dto.Department) *fakeDepartmentRepository {
+	repo := &fakeDepartmentRepository{departments: make(map[int]*dto.Department), nextID: 1}
+	for _, d := range departments {
+		repo.departments[d.ID] = d
+		if d.ID >= repo.nextID {
+			repo.nextID = d.ID + 1
+		}
+	}
+	return repo
+}
+
+func (r *fakeDepartmentRepository) FindByID(ctx context.Context, departmentID int) (*dto.Department, error) {
+	d, ok := r.departments[departmentID]
+	if !ok {
+		return nil, errDepartmentNotFound
+	}
+	copied := *d
+	return &copied, nil
+}
+
+func (r *fakeDepartmentRepository) List(ctx context.Context, limit, offset int) ([]*dto.Department, error) {
+	var all []*dto.Department
+	for _, d := range r.departments {
+		all = append(all, d)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Name < all[j].Name })
+	if offset > len(all) {
+		return nil, nil
+	}
+	all = all[offset:]
+	if limit < len(all) {
+		all = all[:limit]
+	}
+	return all, nil
+}
+
+func (r *fakeDepartmentRepository) Count(ctx context.Context) (int64, error) {
+	return int64(len(r.departments)), nil
+}
+
+func (r *fakeDepartmentRepository) Create(ctx context.Context, department *dto.Department) error {
+	for _, d := range r.departments {
+		if d.Name == department.Name {
+			return apperror.Conflict("department_already_exists", "department already exists")
+		}
+	}
+	department.ID = r.nextID
+	r.nextID++
+	copied := *department
+	r.departments[department.ID] = &copied
+	return nil
+}
+
+func (r *fakeDepartmentRepository) Update(ctx context.Context, department *dto.Department) error {
+	if _, ok := r.departments[department.ID]; !ok {
+		return errDepartmentNotFound
+	}
+	copied := *department
+	r.departments[department.ID] = &copied
+	return nil
+}
+
+func (r *fakeDepartmentRepository) Delete(ctx context.Context, departmentID int) error {
+	if _, ok := r.departments[departmentID]; !ok {
+		return errDepartmentNotFound
+	}
+	delete(r.departments, departmentID)
+	return nil
+}
+
+func TestCreateDepartment(t *testing.T) {
+	repo := newFakeDepartmentRepository()
+	uc := NewDepartmentUsecase(repo, newFakeEmployeeRepository(), fakeTransactor{}, Options{})
+
+	department, err := uc.CreateDepartment(context.Background(), &dto.DepartmentRequest{Name: "  Engineering "})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, department.ID)
+	assert.Equal(t, "Engineering", repo.departments[1].Name)
+}
+
+func TestGetAllDepartments(t *testing.T) {
+	repo := newFakeDepartmentRepository(
+		&dto.Department{ID: 1, Name: "Sales"},
+		&dto.Department{ID: 2, Name: "Engineering"},
+		&dto.Department{ID: 3, Name: "Finance"},
+	)
+	uc := NewDepartmentUsecase(repo, newFakeEmployeeRepository(), fakeTransactor{}, Options{})
+
+	page, err := uc.GetAllDepartments(context.Background(), &dto.GetDepartments{PageSize: 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), page.TotalCount)
+	assert.Equal(t, 2, page.TotalPages)
+	assert.Len(t, page.Departments, 2)
+	assert.Equal(t, "Engineering", page.Departments[0].Name)
+}
+
+func TestUpdateDepartmentNotFound(t *testing.T) {
+	uc := NewDepartmentUsecase(newFakeDepartmentRepository(), newFakeEmployeeRepository(), fakeTransactor{}, Options{})
+
+	_, err := uc.UpdateDepartment(context.Background(), 1, &dto.DepartmentRequest{Name: "Engineering"})
+
+	assert.Equal(t, apperror.KindNotFound, apperror.KindOf(err))
+}
+
+func TestDeleteDepartmentWithEmployees(t *testing.T) {
+	departmentID := 1
+	repo := newFakeDepartmentRepository(&dto.Department{ID: departmentID, Name: "Engineering"})
+	employees := newFakeEmployeeRepository(&dto.Employee{ID: 1, Name: "John Doe", DepartmentID: &departmentID})
+	uc := NewDepartmentUsecase(repo, employees, fakeTransactor{}, Options{})
+	ctx := context.Background()
+
+	err := uc.DeleteDepartment(ctx, departmentID)
+
+	appErr, _ := apperror.As(err)
+	assert.Equal(t, apperror.KindConflict, appErr.Kind)
+	assert.Equal(t, "department_not_empty", appErr.Code)
+
+	assert.NoError(t, employees.Purge(ctx, 1))
+	assert.NoError(t, uc.DeleteDepartment(ctx, departmentID))
+	assert.Empty(t, repo.departments)
+}
@@ -1,169 +1,471 @@
 package usecase
 
 import (
-	"database/sql"
-	"employee-management/api/repository/sqlboiler"
+	"context"
+	"employee-management/domain/apperror"
 	"employee-management/domain/dto"
 	"employee-management/domain/interfaces"
-	"employee-management/utils/convert"
-	"fmt"
+	"employee-management/utils/requestctx"
+	"employee-management/utils/validation"
 	"time"
+)
+
+// Options tunes the employee usecase. Zero values select the defaults.
+type Options struct {
+	// DefaultPageSize is used when a listing does not ask for a page size.
+	DefaultPageSize int
+	// MaxPageSize is the largest page size a listing may ask for.
+	MaxPageSize int
+	// RequirePrecondition rejects updates and deletes without a
+	// precondition.
+	RequirePrecondition bool
+	// DefaultCurrency is the currency of salaries set without one.
+	DefaultCurrency string
+	// MaxImportRows is the largest number of data rows an import may hold.
+	MaxImportRows int
+	// IdempotencyTTL is how long an idempotency key is kept for replays.
+	IdempotencyTTL time.Duration
+}
 
-	"github.com/gin-gonic/gin"
-	"github.com/volatiletech/sqlboiler/v4/boil"
-	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+const (
+	defaultPageSize = 100
+	maxPageSize     = 1000
+	defaultCurrency = "USD"
+	maxImportRows   = 1000
+	idempotencyTTL  = 24 * time.Hour
 )
 
 type employeeUsecase struct {
-	db *sql.DB
+	repo            interfaces.EmployeeRepository
+	audits          interfaces.EmployeeAuditRepository
+	compensations   interfaces.CompensationRepository
+	departments     interfaces.DepartmentRepository
+	idempotencyKeys interfaces.IdempotencyKeyRepository
+	transactor      interfaces.Transactor
+	opts            Options
 }
 
-func NewEmployeeUsecase(db *sql.DB) interfaces.EmployeeUsecase {
+func NewEmployeeUsecase(repo interfaces.EmployeeRepository, audits interfaces.EmployeeAuditRepository, compensations interfaces.CompensationRepository, departments interfaces.DepartmentRepository, idempotencyKeys interfaces.IdempotencyKeyRepository, transactor interfaces.Transactor, opts Options) interfaces.EmployeeUsecase {
 	return &employeeUsecase{
-		db: db,
+		repo:            repo,
+		audits:          audits,
+		compensations:   compensations,
+		departments:     departments,
+		idempotencyKeys: idempotencyKeys,
+		transactor:      transactor,
+		opts:            opts.withDefaults(),
 	}
 }
 
-func (uc *employeeUsecase) GetEmployeeById(ctx *gin.Context, employeeID int) (*dto.Employee, error) {
-	boil.DebugMode = true
-	tx, err := uc.db.BeginTx(ctx, nil)
-	if err != nil {
+// withDefaults fills in the zero values of o.
+func (o Options) withDefaults() Options {
+	if o.DefaultPageSize <= 0 {
+		o.DefaultPageSize = defaultPageSize
+	}
+	if o.MaxPageSize <= 0 {
+		o.MaxPageSize = maxPageSize
+	}
+	if o.DefaultPageSize > o.MaxPageSize {
+		o.DefaultPageSize = o.MaxPageSize
+	}
+	if o.DefaultCurrency == "" {
+		o.DefaultCurrency = defaultCurrency
+	}
+	if o.MaxImportRows <= 0 {
+		o.MaxImportRows = maxImportRows
+	}
+	if o.IdempotencyTTL <= 0 {
+		o.IdempotencyTTL = idempotencyTTL
+	}
+	return o
+}
+
+func (uc *employeeUsecase) GetEmployeeById(ctx context.Context, employeeID int) (*dto.Employee, error) {
+	return uc.repo.FindByID(ctx, employeeID)
+}
+
+func (uc *employeeUsecase) GetAllEmployee(ctx context.Context, request *dto.GetEmployee) (*dto.EmployeePage, error) {
+	if err := validation.Struct(request); err != nil {
 		return nil, err
 	}
-	defer tx.Rollback()
 
-	employee, err := sqlboiler.FindEmployee(ctx, tx, employeeID)
+	query, err := uc.toEmployeeListQuery(request)
 	if err != nil {
 		return nil, err
 	}
-
-	if err := tx.Commit(); err != nil {
+	if err := salaryQueryDenied(ctx, query); err != nil {
 		return nil, err
 	}
 
-	return convert.ToEmployeeDTO(employee), nil
-
-}
+	if request.Keyset() {
+		return uc.keysetPage(ctx, query, request.Limit)
+	}
 
-func (uc *employeeUsecase) GetAllEmployee(ctx *gin.Context, limit int, offset int) ([]*dto.Employee, error) {
-	boil.DebugMode = true
-	tx, err := uc.db.BeginTx(ctx, nil)
+	page := &dto.EmployeePage{PageInfo: dto.PageInfo{
+		Page:     request.Page,
+		PageSize: request.PageSize,
+	}}
+	err = uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if page.Employees, err = uc.repo.List(ctx, query); err != nil {
+			return err
+		}
+		page.TotalCount, err = uc.repo.Count(ctx, query.Filter)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
-	defer tx.Rollback()
 
-	employee, err := sqlboiler.Employees(qm.Limit(limit), qm.Offset(offset)).All(ctx, tx)
+	page.TotalPages = totalPages(page.TotalCount, page.PageSize)
+	return page, nil
+}
+
+// keysetPage lists the page selected by a keyset query and computes the
+// cursor of the next page.
+func (uc *employeeUsecase) keysetPage(ctx context.Context, query dto.EmployeeListQuery, limit int) (*dto.EmployeePage, error) {
+	employees, err := uc.repo.List(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := tx.Commit(); err != nil {
-		return nil, err
+	page := &dto.EmployeePage{
+		PageInfo:  dto.PageInfo{Limit: limit},
+		Employees: employees,
+	}
+	if len(employees) > limit {
+		page.Employees = employees[:limit]
+		if page.NextCursor, err = encodeCursor(query.Sort, page.Employees[limit-1]); err != nil {
+			return nil, err
+		}
 	}
 
-	return convert.ToEmployeeSliceDTO(employee), nil
+	return page, nil
 }
 
-func (uc *employeeUsecase) CreateEmployee(ctx *gin.Context, request *dto.EmployeeCreateRequest) (dto.CreateEmployeeResponse, error) {
-	boil.DebugMode = true
-	tx, err := uc.db.BeginTx(ctx, nil)
-	if err != nil {
+// CreateEmployee creates an employee. Its salary is set as well, so the
+// caller needs the salary:write permission.
+func (uc *employeeUsecase) CreateEmployee(ctx context.Context, request *dto.EmployeeCreateRequest) (dto.CreateEmployeeResponse, error) {
+	if err := validation.Struct(request); err != nil {
+		return dto.CreateEmployeeResponse{}, err
+	}
+	if err := salaryWriteDenied(ctx); err != nil {
 		return dto.CreateEmployeeResponse{}, err
 	}
-	defer tx.Rollback()
 
-	employee := sqlboiler.Employee{
-		Name:      request.Name,
-		Position:  request.Position,
-		Salary:    request.Salary,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+	now := time.Now()
+	employee := &dto.Employee{
+		Name:         request.Name,
+		Position:     request.Position,
+		Salary:       request.Salary,
+		DepartmentID: request.DepartmentID,
+		ManagerID:    request.ManagerID,
+		CreatedAt:    now,
+		UpdatedAt:    now,
 	}
 
-	err = employee.Insert(ctx, uc.db, boil.Infer())
+	var response dto.CreateEmployeeResponse
+	replayed, err := uc.idempotent(ctx, request.IdempotencyKey, request, now, &response, func(ctx context.Context) error {
+		if err := uc.checkDepartment(ctx, employee.DepartmentID); err != nil {
+			return err
+		}
+		if employee.ManagerID != nil {
+			if err := uc.repo.LockHierarchy(ctx); err != nil {
+				return err
+			}
+			if err := uc.checkManager(ctx, 0, employee.ManagerID); err != nil {
+				return err
+			}
+		}
+		if err := uc.repo.Create(ctx, employee); err != nil {
+			return err
+		}
+		response.Id = employee.ID
+		return uc.compensations.Create(ctx, &dto.Compensation{
+			EmployeeID:    employee.ID,
+			Amount:        employee.Salary,
+			Currency:      uc.opts.DefaultCurrency,
+			Reason:        dto.ReasonHire,
+			EffectiveFrom: today(),
+		})
+	})
 	if err != nil {
 		return dto.CreateEmployeeResponse{}, err
 	}
 
-	if err := tx.Commit(); err != nil {
-		return dto.CreateEmployeeResponse{}, err
+	response.Replayed = replayed
+	return response, nil
+}
+
+// UpdateEmployee replaces the name, position and salary of an employee.
+func (uc *employeeUsecase) UpdateEmployee(ctx context.Context, employeeID int, request *dto.UpdateEmployeeBodyRequest, precondition dto.Precondition) (*dto.Employee, error) {
+	if err := validation.Struct(request); err != nil {
+		return nil, err
 	}
 
-	return dto.CreateEmployeeResponse{
-		Id: employee.ID,
-	}, nil
+	return uc.replaceEmployee(ctx, employeeID, precondition, func(*dto.Employee) (*dto.UpdateEmployeeBodyRequest, error) {
+		return request, nil
+	})
 }
 
-func (uc *employeeUsecase) UpdateEmployee(ctx *gin.Context, employeeID int, request *dto.UpdateEmployeeBodyRequest) (*dto.Employee, error) {
-	tx, err := uc.db.BeginTx(ctx, nil)
-	if err != nil {
+// PatchEmployee applies a merge patch or JSON patch to the replaceable
+// fields of an employee and validates the result like a PUT body.
+func (uc *employeeUsecase) PatchEmployee(ctx context.Context, employeeID int, patch dto.EmployeePatch, precondition dto.Precondition) (*dto.Employee, error) {
+	return uc.replaceEmployee(ctx, employeeID, precondition, func(employee *dto.Employee) (*dto.UpdateEmployeeBodyRequest, error) {
+		request, err := applyPatch(employee, patch, !salaryReadable(ctx))
+		if err != nil {
+			return nil, err
+		}
+		if err := validation.Struct(request); err != nil {
+			return nil, err
+		}
+		return request, nil
+	})
+}
+
+// replaceEmployee locks an employee, checks the precondition, asks
+// replacement for the new fields and stores them, all in one transaction.
+func (uc *employeeUsecase) replaceEmployee(ctx context.Context, employeeID int, precondition dto.Precondition, replacement func(*dto.Employee) (*dto.UpdateEmployeeBodyRequest, error)) (*dto.Employee, error) {
+	if err := uc.requirePrecondition(precondition); err != nil {
 		return nil, err
 	}
-	defer tx.Rollback()
 
-	employee := sqlboiler.M{
-		"updated_at": time.Now(),
+	var employee *dto.Employee
+	err := uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		employee, err = uc.repo.LockByID(ctx, employeeID)
+		if err != nil {
+			return err
+		}
+		if err := checkPrecondition(precondition, employee); err != nil {
+			return err
+		}
+
+		request, err := replacement(employee)
+		if err != nil {
+			return err
+		}
+		if !sameReference(employee.DepartmentID, request.DepartmentID) {
+			if err := uc.checkDepartment(ctx, request.DepartmentID); err != nil {
+				return err
+			}
+		}
+		if !sameReference(employee.ManagerID, request.ManagerID) {
+			if err := uc.repo.LockHierarchy(ctx); err != nil {
+				return err
+			}
+			if err := uc.checkManager(ctx, employee.ID, request.ManagerID); err != nil {
+				return err
+			}
+		}
+
+		salaryChanged := employee.Salary != request.Salary
+		if salaryChanged {
+			if err := salaryWriteDenied(ctx); err != nil {
+				return err
+			}
+		}
+		employee.Name = request.Name
+		employee.Position = request.Position
+		employee.Salary = request.Salary
+		employee.DepartmentID = request.DepartmentID
+		employee.ManagerID = request.ManagerID
+
+		if err := uc.repo.Update(ctx, employee); err != nil {
+			return err
+		}
+		if salaryChanged {
+			// a direct salary change takes effect today, replacing any
+			// change already made today
+			err := uc.insertCompensation(ctx, &dto.Compensation{
+				EmployeeID:    employeeID,
+				Amount:        request.Salary,
+				Reason:        dto.ReasonAdjustment,
+				EffectiveFrom: today(),
+			}, true)
+			if err != nil {
+				return err
+			}
+		}
+
+		// reload the employee with its department, like a read
+		employee, err = uc.repo.FindByID(ctx, employeeID)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	if len(request.Name) != 0 {
-		employee["name"] = request.Name
-	}
+	return employee, nil
+}
 
-	if len(request.Position) != 0 {
-		employee["position"] = request.Position
+// salaryReadable reports whether the caller may read salaries.
+func salaryReadable(ctx context.Context) bool {
+	principal, ok := requestctx.Principal(ctx)
+	return !ok || principal.Can(dto.PermissionSalaryRead)
+}
+
+// salaryWriteDenied keeps callers who may not change salaries from setting
+// them through the employee writes; employee:write alone does not allow it.
+func salaryWriteDenied(ctx context.Context) error {
+	principal, ok := requestctx.Principal(ctx)
+	if !ok || principal.Can(dto.PermissionSalaryWrite) {
+		return nil
 	}
+	return apperror.Forbidden("permission_denied", "the salary:write permission is required to set the salary")
+}
 
-	if request.Salary != 0 {
-		employee["salary"] = request.Salary
+func (uc *employeeUsecase) DeleteEmployee(ctx context.Context, employeeID int, precondition dto.Precondition) error {
+	if err := uc.requirePrecondition(precondition); err != nil {
+		return err
 	}
 
-	_, err = sqlboiler.Employees(qm.Where("id=?", employeeID)).UpdateAll(ctx, uc.db, employee)
-	if err != nil {
-		return &dto.Employee{}, err
+	return uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// the row lock waits for writes that assign the employee as a
+		// manager, see checkManager, so the reports checked below stay
+		// the only ones
+		employee, err := uc.repo.LockByID(ctx, employeeID)
+		if err != nil {
+			return err
+		}
+		if err := checkPrecondition(precondition, employee); err != nil {
+			return err
+		}
+		if err := uc.checkNoReports(ctx, employeeID); err != nil {
+			return err
+		}
+		return uc.repo.Delete(ctx, employeeID)
+	})
+}
+
+func (uc *employeeUsecase) RestoreEmployee(ctx context.Context, employeeID int, precondition dto.Precondition) (*dto.Employee, error) {
+	if err := uc.requirePrecondition(precondition); err != nil {
+		return nil, err
 	}
 
-	emp, err := sqlboiler.Employees(qm.Where("id=?", employeeID)).One(ctx, uc.db)
+	var employee *dto.Employee
+	err := uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if len(precondition.IfMatch) > 0 {
+			deleted, err := uc.repo.LockDeletedByID(ctx, employeeID)
+			if err != nil {
+				return err
+			}
+			if err := checkPrecondition(precondition, deleted); err != nil {
+				return err
+			}
+		}
+		var err error
+		employee, err = uc.repo.Restore(ctx, employeeID)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("could not find employee with id %d: %w", employeeID, err)
+		return nil, err
 	}
 
-	if err := tx.Commit(); err != nil {
+	return employee, nil
+}
+
+// PurgeEmployee permanently removes an employee, whether or not it was
+// soft deleted before. Like a delete it is refused while the employee
+// manages someone; soft deleted reports lose their manager instead.
+func (uc *employeeUsecase) PurgeEmployee(ctx context.Context, employeeID int) error {
+	return uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.checkNoReports(ctx, employeeID); err != nil {
+			return err
+		}
+		return uc.repo.Purge(ctx, employeeID)
+	})
+}
+
+// GetEmployeeHistory lists the recorded changes of an employee, newest
+// first. The history outlives deletes and purges; an employee without any
+// history must exist.
+func (uc *employeeUsecase) GetEmployeeHistory(ctx context.Context, employeeID int, request *dto.GetEmployeeHistory) (*dto.EmployeeHistoryPage, error) {
+	if err := validation.Struct(request); err != nil {
 		return nil, err
 	}
+	if fields := uc.opts.offsetBounds(&request.Page, &request.PageSize); len(fields) > 0 {
+		return nil, apperror.ValidationFields("validation_failed", "request validation failed", fields)
+	}
 
-	// Map the retrieved model to your Employee struct
-	employeedata := &dto.Employee{
-		ID:        emp.ID,
-		Name:      emp.Name,
-		Position:  emp.Position,
-		Salary:    emp.Salary,
-		CreatedAt: emp.CreatedAt,
-		UpdatedAt: emp.UpdatedAt,
+	page := &dto.EmployeeHistoryPage{PageInfo: dto.PageInfo{
+		Page:     request.Page,
+		PageSize: request.PageSize,
+	}}
+	err := uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if page.TotalCount, err = uc.audits.Count(ctx, employeeID); err != nil {
+			return err
+		}
+		if page.TotalCount == 0 {
+			_, err = uc.repo.FindByID(ctx, employeeID)
+			return err
+		}
+		page.Entries, err = uc.audits.List(ctx, employeeID, request.PageSize, (request.Page-1)*request.PageSize)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return employeedata, nil
+	page.TotalPages = totalPages(page.TotalCount, page.PageSize)
+	return page, nil
 }
 
-func (uc *employeeUsecase) DeleteEmployee(ctx *gin.Context, employeeID int) error {
-	tx, err := uc.db.BeginTx(ctx, nil)
-	if err != nil {
-		return err
+// GetDepartmentEmployees lists the employees of an existing department.
+func (uc *employeeUsecase) GetDepartmentEmployees(ctx context.Context, departmentID int, request *dto.GetEmployee) (*dto.EmployeePage, error) {
+	if _, err := uc.departments.FindByID(ctx, departmentID); err != nil {
+		return nil, err
 	}
-	defer tx.Rollback()
 
-	employee, err := sqlboiler.FindEmployee(ctx, uc.db, employeeID)
-	if err != nil {
-		return err
-	}
+	request.DepartmentID = []int{departmentID}
+	return uc.GetAllEmployee(ctx, request)
+}
 
-	_, err = sqlboiler.Employees(qm.Where("id = ?", employee.ID)).DeleteAll(ctx, uc.db)
-	if err != nil {
-		return err
+// checkDepartment reports a reference to a department that does not exist
+// as an invalid department_id.
+func (uc *employeeUsecase) checkDepartment(ctx context.Context, departmentID *int) error {
+	if departmentID == nil {
+		return nil
 	}
-	if err := tx.Commit(); err != nil {
-		return err
+	_, err := uc.departments.FindByID(ctx, *departmentID)
+	if apperror.KindOf(err) == apperror.KindNotFound {
+		return apperror.ValidationFields("validation_failed", "request validation failed", []apperror.FieldError{{
+			Pointer: "/department_id",
+			Code:    "field_invalid",
+			Message: "department_id does not reference an existing department",
+		}})
 	}
+	return err
+}
 
+// sameReference reports whether the optional ids a and b are equal.
+func sameReference(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// requirePrecondition rejects unconditional writes when they are disabled.
+func (uc *employeeUsecase) requirePrecondition(precondition dto.Precondition) error {
+	if uc.opts.RequirePrecondition && len(precondition.IfMatch) == 0 {
+		return apperror.New(apperror.KindPreconditionRequired, "precondition_required",
+			"the request must be made conditional with If-Match")
+	}
 	return nil
 }
+
+// checkPrecondition reports whether precondition holds for employee.
+func checkPrecondition(precondition dto.Precondition, employee *dto.Employee) error {
+	if len(precondition.IfMatch) == 0 {
+		return nil
+	}
+	version := employee.Version()
+	for _, match := range precondition.IfMatch {
+		if match == "*" || match == version {
+			return nil
+		}
+	}
+	return apperror.PreconditionFailed("employee_modified", "employee was modified since it was read")
+}
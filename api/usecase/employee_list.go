@@ -0,0 +1,248 @@
+package usecase
+
+import (
+	"context"
+	"employee-management/domain/apperror"
+	"employee-management/domain/dto"
+	"employee-management/utils/requestctx"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// toEmployeeListQuery checks the cross-field rules of a list request and
+// turns it into a repository query. Keyset queries ask for one row more
+// than the page holds to find out whether there is a next page.
+func (uc *employeeUsecase) toEmployeeListQuery(request *dto.GetEmployee) (dto.EmployeeListQuery, error) {
+	var (
+		fields []apperror.FieldError
+		query  dto.EmployeeListQuery
+	)
+
+	if request.Keyset() {
+		fields = append(fields, uc.keysetBounds(request)...)
+	} else {
+		fields = append(fields, uc.opts.offsetBounds(&request.Page, &request.PageSize)...)
+	}
+
+	sortFields, sortErr := parseSort(request.Sort)
+	if sortErr != nil {
+		fields = append(fields, *sortErr)
+	}
+
+	if request.Cursor != "" {
+		cursorSort, after, cursorErr := decodeCursor(request.Cursor)
+		switch {
+		case cursorErr != nil:
+			fields = append(fields, *cursorErr)
+		case request.Sort != "" && sortErr == nil && formatSort(sortFields) != formatSort(cursorSort):
+			fields = append(fields, apperror.FieldError{
+				Pointer: "/sort",
+				Code:    "field_invalid",
+				Message: "sort must match the sort order of the cursor",
+			})
+		default:
+			sortFields, query.After = cursorSort, after
+		}
+	}
+
+	fields = append(fields, employeeFilterProblems(request)...)
+
+	if len(fields) > 0 {
+		return dto.EmployeeListQuery{}, apperror.ValidationFields("validation_failed", "request validation failed", fields)
+	}
+
+	query.Filter = toEmployeeFilter(request)
+	query.Sort = sortFields
+	if request.Keyset() {
+		query.Limit = request.Limit + 1
+	} else {
+		query.Limit = request.PageSize
+		query.Offset = (request.Page - 1) * request.PageSize
+	}
+
+	return query, nil
+}
+
+// employeeFilterProblems checks the rules between the filters of a list
+// request.
+func employeeFilterProblems(request *dto.GetEmployee) []apperror.FieldError {
+	var fields []apperror.FieldError
+	if request.SalaryMin != nil && request.SalaryMax != nil && *request.SalaryMin > *request.SalaryMax {
+		fields = append(fields, apperror.FieldError{
+			Pointer: "/salary_min",
+			Code:    "field_out_of_range",
+			Message: "salary_min must be less than or equal to salary_max",
+		})
+	}
+	if request.IncludeDeleted && request.OnlyDeleted {
+		fields = append(fields, apperror.FieldError{
+			Pointer: "/only_deleted",
+			Code:    "field_invalid",
+			Message: "only_deleted cannot be combined with include_deleted",
+		})
+	}
+	if isAfter(request.CreatedFrom, request.CreatedTo) {
+		fields = append(fields, apperror.FieldError{
+			Pointer: "/created_from",
+			Code:    "field_out_of_range",
+			Message: "created_from must not be after created_to",
+		})
+	}
+	if isAfter(request.UpdatedFrom, request.UpdatedTo) {
+		fields = append(fields, apperror.FieldError{
+			Pointer: "/updated_from",
+			Code:    "field_out_of_range",
+			Message: "updated_from must not be after updated_to",
+		})
+	}
+	return fields
+}
+
+// salaryQueryDenied keeps callers who may not read salaries from filtering
+// or sorting by salary, which would reveal salaries just as well.
+func salaryQueryDenied(ctx context.Context, query dto.EmployeeListQuery) error {
+	principal, ok := requestctx.Principal(ctx)
+	if !ok || principal.Can(dto.PermissionSalaryRead) {
+		return nil
+	}
+	bySalary := query.Filter.SalaryMin != nil || query.Filter.SalaryMax != nil
+	for _, field := range query.Sort {
+		bySalary = bySalary || field.Field == "salary"
+	}
+	if bySalary {
+		return apperror.Forbidden("permission_denied", "the salary:read permission is required to filter or sort by salary")
+	}
+	return nil
+}
+
+// toEmployeeFilter returns the filter selected by a list request.
+func toEmployeeFilter(request *dto.GetEmployee) dto.EmployeeFilter {
+	filter := dto.EmployeeFilter{
+		Positions:     request.Position,
+		SalaryMin:     request.SalaryMin,
+		SalaryMax:     request.SalaryMax,
+		NameContains:  request.Name,
+		CreatedFrom:   request.CreatedFrom,
+		CreatedTo:     request.CreatedTo,
+		UpdatedFrom:   request.UpdatedFrom,
+		UpdatedTo:     request.UpdatedTo,
+		DepartmentIDs: request.DepartmentID,
+	}
+	switch {
+	case request.OnlyDeleted:
+		filter.Deleted = dto.DeletedOnly
+	case request.IncludeDeleted:
+		filter.Deleted = dto.DeletedInclude
+	}
+	return filter
+}
+
+// offsetBounds applies the page defaults and checks the page size.
+func (o Options) offsetBounds(page, pageSize *int) []apperror.FieldError {
+	if *page == 0 {
+		*page = 1
+	}
+	if *pageSize == 0 {
+		*pageSize = o.DefaultPageSize
+	}
+	if *pageSize > o.MaxPageSize {
+		return []apperror.FieldError{{
+			Pointer: "/page_size",
+			Code:    "field_out_of_range",
+			Message: fmt.Sprintf("page_size must be at most %d", o.MaxPageSize),
+		}}
+	}
+	return nil
+}
+
+// totalPages is the number of pages of size pageSize holding count items.
+func totalPages(count int64, pageSize int) int {
+	return int((count + int64(pageSize) - 1) / int64(pageSize))
+}
+
+// keysetBounds applies the limit default and rejects offset parameters.
+func (uc *employeeUsecase) keysetBounds(request *dto.GetEmployee) []apperror.FieldError {
+	var fields []apperror.FieldError
+	if request.Page != 0 || request.PageSize != 0 {
+		fields = append(fields, apperror.FieldError{
+			Pointer: "/page",
+			Code:    "field_invalid",
+			Message: "page and page_size cannot be combined with cursor or limit",
+		})
+	}
+	if request.Limit == 0 {
+		request.Limit = uc.opts.DefaultPageSize
+	}
+	if request.Limit > uc.opts.MaxPageSize {
+		fields = append(fields, apperror.FieldError{
+			Pointer: "/limit",
+			Code:    "field_out_of_range",
+			Message: fmt.Sprintf("limit must be at most %d", uc.opts.MaxPageSize),
+		})
+	}
+	return fields
+}
+
+// parseSort parses "salary,-created_at" into sort fields, accepting only
+// the fields listed in dto.EmployeeSortFields.
+func parseSort(value string) ([]dto.SortField, *apperror.FieldError) {
+	if value == "" {
+		return nil, nil
+	}
+
+	var (
+		fields []dto.SortField
+		seen   = make(map[string]bool)
+	)
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+
+		field := dto.SortField{Field: strings.TrimPrefix(part, "-"), Desc: strings.HasPrefix(part, "-")}
+		if _, ok := dto.EmployeeSortFields[field.Field]; !ok {
+			return nil, &apperror.FieldError{
+				Pointer: "/sort",
+				Code:    "field_invalid",
+				Message: fmt.Sprintf("sort field %q is not one of %s", field.Field, sortableFields()),
+			}
+		}
+		if seen[field.Field] {
+			return nil, &apperror.FieldError{
+				Pointer: "/sort",
+				Code:    "field_invalid",
+				Message: fmt.Sprintf("sort field %q is repeated", field.Field),
+			}
+		}
+		seen[field.Field] = true
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// formatSort is the inverse of parseSort.
+func formatSort(fields []dto.SortField) string {
+	parts := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if field.Desc {
+			parts = append(parts, "-"+field.Field)
+		} else {
+			parts = append(parts, field.Field)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+func sortableFields() string {
+	names := make([]string, 0, len(dto.EmployeeSortFields))
+	for name := range dto.EmployeeSortFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+func isAfter(from *time.Time, to *time.Time) bool {
+	return from != nil && to != nil && from.After(*to)
+}
@@ -1,193 +1,997 @@
 package usecase
 
 import (
+	"context"
+	"employee-management/domain/apperror"
 	"employee-management/domain/dto"
-	"regexp"
+	"employee-management/utils/requestctx"
+	"slices"
+	"sort"
 	"testing"
 	"time"
 
-	"github.com/DATA-DOG/go-sqlmock"
-	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 )
 
-func TestGetEmployeeById(t *testing.T) {
-	db, mock, err := sqlmock.New()
-	if err != nil {
-		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+var errEmployeeNotFound = apperror.NotFound("employee_not_found", "employee not found")
+
+// fakeEmployeeRepository is an in-memory interfaces.EmployeeRepository.
+type fakeEmployeeRepository struct {
+	employees       map[int]*dto.Employee
+	nextID          int
+	lastQuery       dto.EmployeeListQuery
+	lastStatsQuery  dto.EmployeeStatsQuery
+	lastSearchQuery dto.EmployeeSearchQuery
+	// departments, when set, are loaded by FindByID like the postgres
+	// repository does.
+	departments map[int]*dto.Department
+	// hierarchyLocks counts the LockHierarchy calls.
+	hierarchyLocks int
+}
+
+func newFakeEmployeeRepository(employees ...*dto.Employee) *fakeEmployeeRepository {
+	repo := &fakeEmployeeRepository{employees: make(map[int]*dto.Employee), nextID: 1}
+	for _, e := range employees {
+		repo.employees[e.ID] = e
+		if e.ID >= repo.nextID {
+			repo.nextID = e.ID + 1
+		}
+	}
+	return repo
+}
+
+func (r *fakeEmployeeRepository) FindByID(ctx context.Context, employeeID int) (*dto.Employee, error) {
+	e, ok := r.employees[employeeID]
+	if !ok || e.DeletedAt != nil {
+		return nil, errEmployeeNotFound
+	}
+	copied := *e
+	if e.DepartmentID != nil && r.departments != nil {
+		copied.Department = r.departments[*e.DepartmentID]
+	}
+	return &copied, nil
+}
+
+func (r *fakeEmployeeRepository) LockByID(ctx context.Context, employeeID int) (*dto.Employee, error) {
+	return r.FindByID(ctx, employeeID)
+}
+
+func (r *fakeEmployeeRepository) LockSharedByID(ctx context.Context, employeeID int) (*dto.Employee, error) {
+	return r.FindByID(ctx, employeeID)
+}
+
+func (r *fakeEmployeeRepository) LockDeletedByID(ctx context.Context, employeeID int) (*dto.Employee, error) {
+	e, ok := r.employees[employeeID]
+	if !ok || e.DeletedAt == nil {
+		return nil, errEmployeeNotFound
+	}
+	copied := *e
+	return &copied, nil
+}
+
+func (r *fakeEmployeeRepository) List(ctx context.Context, query dto.EmployeeListQuery) ([]*dto.Employee, error) {
+	r.lastQuery = query
+	limit, offset := query.Limit, query.Offset
+
+	// the fake only orders by id
+	var all []*dto.Employee
+	for id := 1; id < r.nextID; id++ {
+		if e, ok := r.employees[id]; ok && visible(e, query.Filter) && (query.After == nil || id > query.After.ID) {
+			all = append(all, e)
+		}
+	}
+	if offset > len(all) {
+		return nil, nil
+	}
+	all = all[offset:]
+	if limit < len(all) {
+		all = all[:limit]
+	}
+	return all, nil
+}
+
+func (r *fakeEmployeeRepository) Count(ctx context.Context, filter dto.EmployeeFilter) (int64, error) {
+	var count int64
+	for _, e := range r.employees {
+		if visible(e, filter) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// visible applies the soft delete and department parts of filter, the
+// only parts the fake understands.
+func visible(e *dto.Employee, filter dto.EmployeeFilter) bool {
+	if len(filter.DepartmentIDs) > 0 && (e.DepartmentID == nil || !slices.Contains(filter.DepartmentIDs, *e.DepartmentID)) {
+		return false
+	}
+	switch filter.Deleted {
+	case dto.DeletedExclude:
+		return e.DeletedAt == nil
+	case dto.DeletedOnly:
+		return e.DeletedAt != nil
+	}
+	return true
+}
+
+func (r *fakeEmployeeRepository) Create(ctx context.Context, employee *dto.Employee) error {
+	employee.ID = r.nextID
+	r.nextID++
+	copied := *employee
+	r.employees[employee.ID] = &copied
+	return nil
+}
+
+func (r *fakeEmployeeRepository) Update(ctx context.Context, employee *dto.Employee) error {
+	if e, ok := r.employees[employee.ID]; !ok || e.DeletedAt != nil {
+		return errEmployeeNotFound
+	}
+	employee.UpdatedAt = time.Now()
+	copied := *employee
+	r.employees[employee.ID] = &copied
+	return nil
+}
+
+func (r *fakeEmployeeRepository) Delete(ctx context.Context, employeeID int) error {
+	e, ok := r.employees[employeeID]
+	if !ok || e.DeletedAt != nil {
+		return errEmployeeNotFound
+	}
+	now := time.Now()
+	e.DeletedAt = &now
+	e.UpdatedAt = now
+	return nil
+}
+
+func (r *fakeEmployeeRepository) Restore(ctx context.Context, employeeID int) (*dto.Employee, error) {
+	e, ok := r.employees[employeeID]
+	if !ok || e.DeletedAt == nil {
+		return nil, errEmployeeNotFound
+	}
+	e.DeletedAt = nil
+	e.UpdatedAt = time.Now()
+	return r.FindByID(ctx, employeeID)
+}
+
+func (r *fakeEmployeeRepository) Purge(ctx context.Context, employeeID int) error {
+	if _, ok := r.employees[employeeID]; !ok {
+		return errEmployeeNotFound
+	}
+	for _, e := range r.employees {
+		if e.DeletedAt != nil && e.ManagerID != nil && *e.ManagerID == employeeID {
+			e.ManagerID = nil
+		}
+	}
+	delete(r.employees, employeeID)
+	return nil
+}
+
+// fakeAuditRepository is an in-memory interfaces.EmployeeAuditRepository.
+type fakeAuditRepository struct {
+	audits []*dto.EmployeeAudit
+}
+
+func newFakeAuditRepository(audits ...*dto.EmployeeAudit) *fakeAuditRepository {
+	return &fakeAuditRepository{audits: audits}
+}
+
+func (r *fakeAuditRepository) List(ctx context.Context, employeeID int, limit, offset int) ([]*dto.EmployeeAudit, error) {
+	var matching []*dto.EmployeeAudit
+	for i := len(r.audits) - 1; i >= 0; i-- {
+		if r.audits[i].EmployeeID == employeeID {
+			matching = append(matching, r.audits[i])
+		}
+	}
+	if offset > len(matching) {
+		return nil, nil
+	}
+	matching = matching[offset:]
+	if limit < len(matching) {
+		matching = matching[:limit]
+	}
+	return matching, nil
+}
+
+func (r *fakeAuditRepository) Count(ctx context.Context, employeeID int) (int64, error) {
+	var count int64
+	for _, audit := range r.audits {
+		if audit.EmployeeID == employeeID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// fakeCompensationRepository is an in-memory interfaces.CompensationRepository.
+type fakeCompensationRepository struct {
+	compensations []*dto.Compensation
+}
+
+func newFakeCompensationRepository(compensations ...*dto.Compensation) *fakeCompensationRepository {
+	return &fakeCompensationRepository{compensations: compensations}
+}
+
+func (r *fakeCompensationRepository) List(ctx context.Context, employeeID int) ([]*dto.Compensation, error) {
+	var timeline []*dto.Compensation
+	for _, compensation := range r.compensations {
+		if compensation.EmployeeID == employeeID {
+			stored := *compensation
+			timeline = append(timeline, &stored)
+		}
 	}
-	defer db.Close()
+	sort.Slice(timeline, func(i, j int) bool {
+		return timeline[i].EffectiveFrom.Before(timeline[j].EffectiveFrom)
+	})
+	return timeline, nil
+}
 
-	employeeID := 1
-	expectedEmployee := &dto.Employee{
-		ID:        employeeID,
-		Name:      "John Doe",
-		Position:  "Developer",
-		Salary:    60000,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+func (r *fakeCompensationRepository) Create(ctx context.Context, compensation *dto.Compensation) error {
+	compensation.ID = int64(len(r.compensations) + 1)
+	stored := *compensation
+	r.compensations = append(r.compensations, &stored)
+	return nil
+}
+
+func (r *fakeCompensationRepository) Update(ctx context.Context, compensation *dto.Compensation) error {
+	for _, stored := range r.compensations {
+		if stored.ID == compensation.ID {
+			stored.Amount = compensation.Amount
+			stored.Currency = compensation.Currency
+			stored.Reason = compensation.Reason
+			stored.EffectiveTo = compensation.EffectiveTo
+			return nil
+		}
 	}
+	return apperror.NotFound("compensation_not_found", "compensation not found")
+}
 
-	rows := sqlmock.NewRows([]string{"id", "name", "position", "salary", "created_at", "updated_at"}).
-		AddRow(employeeID, "John Doe", "Developer", 60000, time.Now(), time.Now())
+// fakeTransactor runs the unit of work without a real transaction.
+type fakeTransactor struct{}
 
-	mock.ExpectBegin()
-	mock.ExpectQuery(regexp.QuoteMeta(`select * from "employee" where "id"=$1`)).WithArgs(employeeID).WillReturnRows(rows)
-	mock.ExpectCommit()
+func (fakeTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
 
-	uc := NewEmployeeUsecase(db)
-	ctx := &gin.Context{}
+// fakeIdempotencyKeyRepository is an in-memory
+// interfaces.IdempotencyKeyRepository.
+type fakeIdempotencyKeyRepository struct {
+	keys map[[2]string]*dto.IdempotencyKey
+}
+
+func newFakeIdempotencyKeyRepository() *fakeIdempotencyKeyRepository {
+	return &fakeIdempotencyKeyRepository{keys: make(map[[2]string]*dto.IdempotencyKey)}
+}
 
-	employee, err := uc.GetEmployeeById(ctx, employeeID)
+func (r *fakeIdempotencyKeyRepository) Claim(ctx context.Context, key *dto.IdempotencyKey) (*dto.IdempotencyKey, error) {
+	key.CreatedAt = time.Now()
+	if stored, ok := r.keys[[2]string{key.Scope, key.Key}]; ok && stored.ExpiresAt.After(key.CreatedAt) {
+		copied := *stored
+		return &copied, nil
+	}
+	stored := *key
+	r.keys[[2]string{key.Scope, key.Key}] = &stored
+	return nil, nil
+}
+
+func (r *fakeIdempotencyKeyRepository) SaveResponse(ctx context.Context, key *dto.IdempotencyKey) error {
+	r.keys[[2]string{key.Scope, key.Key}].Response = key.Response
+	return nil
+}
+
+func TestGetEmployeeById(t *testing.T) {
+	repo := newFakeEmployeeRepository(&dto.Employee{ID: 1, Name: "John Doe", Position: "Developer", Salary: 60000})
+	uc := NewEmployeeUsecase(repo, newFakeAuditRepository(), newFakeCompensationRepository(), newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+	ctx := context.Background()
+
+	employee, err := uc.GetEmployeeById(ctx, 1)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, employee)
-	assert.Equal(t, expectedEmployee.Name, employee.Name)
-	assert.Equal(t, expectedEmployee.Position, employee.Position)
-	assert.Equal(t, expectedEmployee.Salary, employee.Salary)
-	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, "John Doe", employee.Name)
+	assert.Equal(t, "Developer", employee.Position)
+	assert.Equal(t, float64(60000), employee.Salary)
 }
 
 func TestGetAllEmployee(t *testing.T) {
-	db, mock, err := sqlmock.New()
-	if err != nil {
-		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
-	}
-	defer db.Close()
-
-	expectedEmployees := []*dto.Employee{
-		{
-			ID:        1,
-			Name:      "John Doe",
-			Position:  "Developer",
-			Salary:    60000,
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
-		},
-		{
-			ID:        2,
-			Name:      "Jane Smith",
-			Position:  "Manager",
-			Salary:    80000,
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
+	repo := newFakeEmployeeRepository(
+		&dto.Employee{ID: 1, Name: "John Doe", Position: "Developer", Salary: 60000},
+		&dto.Employee{ID: 2, Name: "Jane Smith", Position: "Manager", Salary: 80000},
+		&dto.Employee{ID: 3, Name: "Alice Johnson", Position: "Manager", Salary: 87000},
+	)
+	uc := NewEmployeeUsecase(repo, newFakeAuditRepository(), newFakeCompensationRepository(), newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+	ctx := context.Background()
+
+	page, err := uc.GetAllEmployee(ctx, &dto.GetEmployee{Page: 2, PageSize: 1})
+
+	assert.NoError(t, err)
+	assert.Len(t, page.Employees, 1)
+	assert.Equal(t, "Jane Smith", page.Employees[0].Name)
+	assert.Equal(t, int64(3), page.TotalCount)
+	assert.Equal(t, 3, page.TotalPages)
+}
+
+func TestGetAllEmployeePageBounds(t *testing.T) {
+	repo := newFakeEmployeeRepository()
+	uc := NewEmployeeUsecase(repo, newFakeAuditRepository(), newFakeCompensationRepository(), newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{DefaultPageSize: 10, MaxPageSize: 50})
+	ctx := context.Background()
+
+	page, err := uc.GetAllEmployee(ctx, &dto.GetEmployee{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, page.Page)
+	assert.Equal(t, 10, page.PageSize)
+	assert.Equal(t, 0, repo.lastQuery.Offset)
+
+	_, err = uc.GetAllEmployee(ctx, &dto.GetEmployee{Page: -1, PageSize: 51})
+	appErr, _ := apperror.As(err)
+	assert.Equal(t, apperror.KindValidation, appErr.Kind)
+	assert.Len(t, appErr.Fields, 1)
+	assert.Equal(t, "/page", appErr.Fields[0].Pointer)
+
+	_, err = uc.GetAllEmployee(ctx, &dto.GetEmployee{Page: 1, PageSize: 51})
+	appErr, _ = apperror.As(err)
+	assert.Equal(t, "/page_size", appErr.Fields[0].Pointer)
+}
+
+func TestGetAllEmployeeBuildsQuery(t *testing.T) {
+	repo := newFakeEmployeeRepository()
+	uc := NewEmployeeUsecase(repo, newFakeAuditRepository(), newFakeCompensationRepository(), newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+	ctx := context.Background()
+	salaryMin := 1000.0
+
+	_, err := uc.GetAllEmployee(ctx, &dto.GetEmployee{
+		Page:      1,
+		Position:  []string{"Developer, Manager", " QA "},
+		SalaryMin: &salaryMin,
+		Name:      " jo ",
+		Sort:      "salary,-created_at",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, dto.EmployeeListQuery{
+		Filter: dto.EmployeeFilter{
+			Positions:    []string{"Developer", "Manager", "QA"},
+			SalaryMin:    &salaryMin,
+			NameContains: "jo",
 		},
-	}
+		Sort:  []dto.SortField{{Field: "salary"}, {Field: "created_at", Desc: true}},
+		Limit: 100,
+	}, repo.lastQuery)
+}
 
-	rows := sqlmock.NewRows([]string{"id", "name", "position", "salary", "created_at", "updated_at"}).
-		AddRow(1, "John Doe", "Developer", 60000, time.Now(), time.Now()).
-		AddRow(2, "Jane Smith", "Manager", 80000, time.Now(), time.Now())
+func TestGetAllEmployeeRejectsInvalidQuery(t *testing.T) {
+	uc := NewEmployeeUsecase(newFakeEmployeeRepository(), newFakeAuditRepository(), newFakeCompensationRepository(), newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+	ctx := context.Background()
+	salaryMin, salaryMax := 2000.0, 1000.0
+
+	_, err := uc.GetAllEmployee(ctx, &dto.GetEmployee{
+		Page:      1,
+		SalaryMin: &salaryMin,
+		SalaryMax: &salaryMax,
+		Sort:      "password",
+	})
+
+	appErr, ok := apperror.As(err)
+	assert.True(t, ok)
+	assert.Equal(t, apperror.KindValidation, appErr.Kind)
+	assert.Len(t, appErr.Fields, 2)
+	assert.Equal(t, "/sort", appErr.Fields[0].Pointer)
+	assert.Equal(t, "/salary_min", appErr.Fields[1].Pointer)
+}
 
-	mock.ExpectBegin()
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "employee".* FROM "employee" LIMIT 10 OFFSET 5`)).WillReturnRows(rows)
-	mock.ExpectCommit()
+func TestGetAllEmployeeKeyset(t *testing.T) {
+	repo := newFakeEmployeeRepository(
+		&dto.Employee{ID: 1, Name: "John Doe", Position: "Developer", Salary: 60000},
+		&dto.Employee{ID: 2, Name: "Jane Smith", Position: "Manager", Salary: 80000},
+		&dto.Employee{ID: 3, Name: "Alice Johnson", Position: "Manager", Salary: 87000},
+	)
+	uc := NewEmployeeUsecase(repo, newFakeAuditRepository(), newFakeCompensationRepository(), newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+	ctx := context.Background()
 
-	uc := NewEmployeeUsecase(db)
-	ctx := &gin.Context{}
+	page, err := uc.GetAllEmployee(ctx, &dto.GetEmployee{Limit: 2})
+	assert.NoError(t, err)
+	assert.Len(t, page.Employees, 2)
+	assert.Equal(t, 3, repo.lastQuery.Limit)
+	assert.NotEmpty(t, page.NextCursor)
 
-	employees, err := uc.GetAllEmployee(ctx, 10, 5)
+	page, err = uc.GetAllEmployee(ctx, &dto.GetEmployee{Cursor: page.NextCursor, Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, repo.lastQuery.After.ID)
+	assert.Len(t, page.Employees, 1)
+	assert.Equal(t, "Alice Johnson", page.Employees[0].Name)
+	assert.Empty(t, page.NextCursor)
+}
 
+func TestGetAllEmployeeCursorKeepsSort(t *testing.T) {
+	repo := newFakeEmployeeRepository()
+	uc := NewEmployeeUsecase(repo, newFakeAuditRepository(), newFakeCompensationRepository(), newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+	ctx := context.Background()
+
+	cursor, err := encodeCursor([]dto.SortField{{Field: "salary", Desc: true}}, &dto.Employee{ID: 4, Name: "John", Salary: 60000})
+	assert.NoError(t, err)
+
+	_, err = uc.GetAllEmployee(ctx, &dto.GetEmployee{Cursor: cursor})
 	assert.NoError(t, err)
-	assert.Len(t, employees, 2)
-	assert.Equal(t, expectedEmployees[0].Name, employees[0].Name)
-	assert.Equal(t, expectedEmployees[1].Name, employees[1].Name)
-	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, []dto.SortField{{Field: "salary", Desc: true}}, repo.lastQuery.Sort)
+	assert.Equal(t, &dto.Employee{ID: 4, Salary: 60000}, repo.lastQuery.After)
+
+	_, err = uc.GetAllEmployee(ctx, &dto.GetEmployee{Cursor: cursor, Sort: "name"})
+	appErr, _ := apperror.As(err)
+	assert.Equal(t, "/sort", appErr.Fields[0].Pointer)
+
+	_, err = uc.GetAllEmployee(ctx, &dto.GetEmployee{Cursor: "not-a-cursor", Page: 2})
+	appErr, _ = apperror.As(err)
+	assert.Len(t, appErr.Fields, 2)
+	assert.Equal(t, "/page", appErr.Fields[0].Pointer)
+	assert.Equal(t, "/cursor", appErr.Fields[1].Pointer)
 }
 
 func TestCreateEmployee(t *testing.T) {
-	db, mock, err := sqlmock.New()
-	if err != nil {
-		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
-	}
-	defer db.Close()
+	repo := newFakeEmployeeRepository()
+	uc := NewEmployeeUsecase(repo, newFakeAuditRepository(), newFakeCompensationRepository(), newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+	ctx := context.Background()
 
-	var id int
-	id = 1
 	request := &dto.EmployeeCreateRequest{
 		Name:     "John Doe",
 		Position: "Developer",
 		Salary:   60000,
 	}
 
-	mock.ExpectBegin()
-	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "employee" ("name","position","salary","created_at","updated_at") VALUES ($1,$2,$3,$4,$5) RETURNING "id"`)).
-		WithArgs(request.Name, request.Position, request.Salary, sqlmock.AnyArg(), sqlmock.AnyArg()).
-		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
-	mock.ExpectCommit()
+	response, err := uc.CreateEmployee(ctx, request)
 
-	uc := NewEmployeeUsecase(db)
-	ctx := &gin.Context{}
+	assert.NoError(t, err)
+	assert.Equal(t, 1, response.Id)
+	stored := repo.employees[response.Id]
+	assert.Equal(t, request.Name, stored.Name)
+	assert.False(t, stored.CreatedAt.IsZero())
+	assert.False(t, stored.UpdatedAt.IsZero())
+}
 
-	response, err := uc.CreateEmployee(ctx, request)
+func TestCreateEmployeeIdempotencyKey(t *testing.T) {
+	repo := newFakeEmployeeRepository()
+	keys := newFakeIdempotencyKeyRepository()
+	uc := NewEmployeeUsecase(repo, newFakeAuditRepository(), newFakeCompensationRepository(), newFakeDepartmentRepository(), keys, fakeTransactor{}, Options{})
+	alice := requestctx.WithPrincipal(context.Background(), &dto.Principal{Subject: "alice", Permissions: dto.Permissions})
+	bob := requestctx.WithPrincipal(context.Background(), &dto.Principal{Subject: "bob", Permissions: dto.Permissions})
+	request := func() *dto.EmployeeCreateRequest {
+		return &dto.EmployeeCreateRequest{Name: "John Doe", Position: "Developer", Salary: 60000, IdempotencyKey: "7f1c"}
+	}
+
+	first, err := uc.CreateEmployee(alice, request())
+	assert.NoError(t, err)
+	assert.False(t, first.Replayed)
 
+	retry, err := uc.CreateEmployee(alice, request())
+	assert.NoError(t, err)
+	assert.Equal(t, dto.CreateEmployeeResponse{Id: first.Id, Replayed: true}, retry)
+	assert.Len(t, repo.employees, 1)
+
+	// keys are scoped to the caller
+	other, err := uc.CreateEmployee(bob, request())
+	assert.NoError(t, err)
+	assert.NotEqual(t, first.Id, other.Id)
+
+	changed := request()
+	changed.Salary = 65000
+	_, err = uc.CreateEmployee(alice, changed)
+	appErr, ok := apperror.As(err)
+	assert.True(t, ok)
+	assert.Equal(t, apperror.KindValidation, appErr.Kind)
+	assert.Equal(t, "idempotency_key_reused", appErr.Code)
+
+	// an expired key may be used again
+	keys.keys[[2]string{"alice", "7f1c"}].ExpiresAt = time.Now().Add(-time.Second)
+	again, err := uc.CreateEmployee(alice, changed)
 	assert.NoError(t, err)
-	assert.Equal(t, id, response.Id)
-	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.False(t, again.Replayed)
+	assert.Len(t, repo.employees, 3)
 }
 
 func TestUpdateEmployee(t *testing.T) {
-	db, mock, err := sqlmock.New()
-	if err != nil {
-		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
-	}
-	defer db.Close()
+	repo := newFakeEmployeeRepository(&dto.Employee{ID: 1, Name: "John Doe", Position: "Developer", Salary: 60000})
+	uc := NewEmployeeUsecase(repo, newFakeAuditRepository(), newFakeCompensationRepository(), newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+	ctx := context.Background()
 
-	employeeID := 1
 	request := &dto.UpdateEmployeeBodyRequest{
 		Name:     "John Doe Updated",
 		Position: "Senior Developer",
 		Salary:   70000,
 	}
 
-	mock.ExpectBegin()
+	employee, err := uc.UpdateEmployee(ctx, 1, request, dto.Precondition{})
 
-	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "employee" SET "name" = $1, "position" = $2, "salary" = $3, "updated_at" = $4 WHERE (id=$5)`)).
-		WithArgs(request.Name, request.Position, request.Salary, sqlmock.AnyArg(), employeeID).
-		WillReturnResult(sqlmock.NewResult(1, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, request.Name, employee.Name)
+	assert.Equal(t, request.Position, employee.Position)
+	assert.Equal(t, request.Salary, employee.Salary)
+}
 
-		// Mock the select query after update
-	rows := sqlmock.NewRows([]string{"id", "name", "position", "salary", "created_at", "updated_at"}).
-		AddRow(employeeID, request.Name, request.Position, request.Salary, time.Now(), time.Now())
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "employee".* FROM "employee" WHERE (id=$1) LIMIT 1`)).WithArgs(employeeID).WillReturnRows(rows)
+func TestEmployeeWritesRequireSalaryWrite(t *testing.T) {
+	repo := newFakeEmployeeRepository(&dto.Employee{ID: 1, Name: "John Doe", Position: "Developer", Salary: 60000})
+	uc := NewEmployeeUsecase(repo, newFakeAuditRepository(), newFakeCompensationRepository(), newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+	clerk := requestctx.WithPrincipal(context.Background(), &dto.Principal{
+		Subject:     "alice",
+		Permissions: []string{dto.PermissionEmployeeRead, dto.PermissionEmployeeWrite},
+	})
+
+	_, err := uc.CreateEmployee(clerk, &dto.EmployeeCreateRequest{Name: "Jane Doe", Position: "Developer", Salary: 60000})
+	assert.Equal(t, apperror.KindForbidden, apperror.KindOf(err))
+
+	_, err = uc.UpdateEmployee(clerk, 1, &dto.UpdateEmployeeBodyRequest{Name: "John Doe", Position: "Developer", Salary: 90000}, dto.Precondition{})
+	assert.Equal(t, apperror.KindForbidden, apperror.KindOf(err))
+	_, err = uc.PatchEmployee(clerk, 1, dto.EmployeePatch{Format: dto.PatchMerge, Document: []byte(`{"salary":90000}`)}, dto.Precondition{})
+	assert.Equal(t, apperror.KindForbidden, apperror.KindOf(err))
+	assert.Equal(t, 60000.0, repo.employees[1].Salary)
+
+	// keeping the salary as it is needs no salary:write
+	employee, err := uc.UpdateEmployee(clerk, 1, &dto.UpdateEmployeeBodyRequest{Name: "John Doe", Position: "Lead", Salary: 60000}, dto.Precondition{})
+	assert.NoError(t, err)
+	assert.Equal(t, "Lead", employee.Position)
+}
+
+func TestUpdateEmployeeRequiresAllFields(t *testing.T) {
+	repo := newFakeEmployeeRepository(&dto.Employee{ID: 1, Name: "John Doe", Position: "Developer", Salary: 60000})
+	uc := NewEmployeeUsecase(repo, newFakeAuditRepository(), newFakeCompensationRepository(), newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+	ctx := context.Background()
 
-	mock.ExpectCommit()
+	_, err := uc.UpdateEmployee(ctx, 1, &dto.UpdateEmployeeBodyRequest{Position: "Lead Engineer"}, dto.Precondition{})
+
+	appErr, _ := apperror.As(err)
+	assert.Equal(t, apperror.KindValidation, appErr.Kind)
+	assert.Len(t, appErr.Fields, 2)
+	assert.Equal(t, "Developer", repo.employees[1].Position)
+}
 
-	uc := NewEmployeeUsecase(db)
-	ctx := &gin.Context{}
+func TestPatchEmployeeMergePatch(t *testing.T) {
+	repo := newFakeEmployeeRepository(&dto.Employee{ID: 1, Name: "John Doe", Position: "Developer", Salary: 60000})
+	uc := NewEmployeeUsecase(repo, newFakeAuditRepository(), newFakeCompensationRepository(), newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+	ctx := context.Background()
 
-	employee, err := uc.UpdateEmployee(ctx, employeeID, request)
+	employee, err := uc.PatchEmployee(ctx, 1, dto.EmployeePatch{
+		Format:   dto.PatchMerge,
+		Document: []byte(`{"position":" Lead Engineer "}`),
+	}, dto.Precondition{})
 
 	assert.NoError(t, err)
-	assert.Equal(t, request.Name, employee.Name)
-	assert.Equal(t, request.Position, employee.Position)
-	assert.Equal(t, request.Salary, employee.Salary)
-	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, "John Doe", employee.Name)
+	assert.Equal(t, "Lead Engineer", employee.Position)
+	assert.Equal(t, float64(60000), employee.Salary)
+
+	_, err = uc.PatchEmployee(ctx, 1, dto.EmployeePatch{
+		Format:   dto.PatchMerge,
+		Document: []byte(`{"name":null,"salary":"a lot","id":2}`),
+	}, dto.Precondition{})
+	appErr, _ := apperror.As(err)
+	assert.Equal(t, apperror.KindValidation, appErr.Kind)
+	assert.Equal(t, []string{"/id", "/salary"}, []string{appErr.Fields[0].Pointer, appErr.Fields[1].Pointer})
+
+	_, err = uc.PatchEmployee(ctx, 1, dto.EmployeePatch{
+		Format:   dto.PatchMerge,
+		Document: []byte(`{"name":null}`),
+	}, dto.Precondition{})
+	appErr, _ = apperror.As(err)
+	assert.Equal(t, "/name", appErr.Fields[0].Pointer)
+	assert.Equal(t, "field_required", appErr.Fields[0].Code)
+	assert.Equal(t, "John Doe", repo.employees[1].Name)
 }
 
-func TestDeleteEmployee(t *testing.T) {
-	db, mock, err := sqlmock.New()
-	if err != nil {
-		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+func TestPatchEmployeeJSONPatch(t *testing.T) {
+	repo := newFakeEmployeeRepository(&dto.Employee{ID: 1, Name: "John Doe", Position: "Developer", Salary: 60000})
+	uc := NewEmployeeUsecase(repo, newFakeAuditRepository(), newFakeCompensationRepository(), newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+	ctx := context.Background()
+
+	employee, err := uc.PatchEmployee(ctx, 1, dto.EmployeePatch{
+		Format:   dto.PatchJSON,
+		Document: []byte(`[{"op":"test","path":"/salary","value":60000},{"op":"replace","path":"/salary","value":65000}]`),
+	}, dto.Precondition{})
+	assert.NoError(t, err)
+	assert.Equal(t, float64(65000), employee.Salary)
+
+	_, err = uc.PatchEmployee(ctx, 1, dto.EmployeePatch{
+		Format:   dto.PatchJSON,
+		Document: []byte(`[{"op":"test","path":"/salary","value":60000},{"op":"replace","path":"/salary","value":1}]`),
+	}, dto.Precondition{})
+	assert.Equal(t, apperror.KindConflict, apperror.KindOf(err))
+	assert.Equal(t, float64(65000), repo.employees[1].Salary)
+
+	_, err = uc.PatchEmployee(ctx, 1, dto.EmployeePatch{Format: dto.PatchJSON, Document: []byte(`{"op":"add"}`)}, dto.Precondition{})
+	assert.Equal(t, apperror.KindInvalidInput, apperror.KindOf(err))
+}
+
+func TestPatchEmployeeHidesSalary(t *testing.T) {
+	repo := newFakeEmployeeRepository(&dto.Employee{ID: 1, Name: "John Doe", Position: "Developer", Salary: 60000})
+	uc := NewEmployeeUsecase(repo, newFakeAuditRepository(), newFakeCompensationRepository(), newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+	clerk := requestctx.WithPrincipal(context.Background(), &dto.Principal{
+		Subject:     "alice",
+		Permissions: []string{dto.PermissionEmployeeRead, dto.PermissionEmployeeWrite},
+	})
+
+	// neither a right nor a wrong guess tells the salary
+	for _, guess := range []string{"60000", "1"} {
+		_, err := uc.PatchEmployee(clerk, 1, dto.EmployeePatch{
+			Format:   dto.PatchJSON,
+			Document: []byte(`[{"op":"test","path":"/salary","value":` + guess + `}]`),
+		}, dto.Precondition{})
+		assert.Equal(t, apperror.KindForbidden, apperror.KindOf(err))
 	}
-	defer db.Close()
+	_, err := uc.PatchEmployee(clerk, 1, dto.EmployeePatch{
+		Format:   dto.PatchJSON,
+		Document: []byte(`[{"op":"copy","from":"/salary","path":"/position"}]`),
+	}, dto.Precondition{})
+	assert.Equal(t, apperror.KindForbidden, apperror.KindOf(err))
+
+	// patches that leave the salary alone keep it
+	employee, err := uc.PatchEmployee(clerk, 1, dto.EmployeePatch{
+		Format:   dto.PatchJSON,
+		Document: []byte(`[{"op":"replace","path":"/position","value":"Lead"}]`),
+	}, dto.Precondition{})
+	assert.NoError(t, err)
+	assert.Equal(t, "Lead", employee.Position)
+	assert.Equal(t, 60000.0, employee.Salary)
+}
+
+func TestUpdateEmployeeNotFound(t *testing.T) {
+	uc := NewEmployeeUsecase(newFakeEmployeeRepository(), newFakeAuditRepository(), newFakeCompensationRepository(), newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+	ctx := context.Background()
+
+	_, err := uc.UpdateEmployee(ctx, 1, &dto.UpdateEmployeeBodyRequest{Name: "John", Position: "Developer", Salary: 1}, dto.Precondition{})
+
+	assert.Equal(t, apperror.KindNotFound, apperror.KindOf(err))
+}
+
+func TestUpdateEmployeePrecondition(t *testing.T) {
+	updatedAt := time.Date(2024, 6, 16, 11, 36, 17, 864376000, time.UTC)
+	repo := newFakeEmployeeRepository(&dto.Employee{ID: 1, Name: "John Doe", Position: "Developer", Salary: 60000, UpdatedAt: updatedAt})
+	uc := NewEmployeeUsecase(repo, newFakeAuditRepository(), newFakeCompensationRepository(), newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{RequirePrecondition: true})
+	ctx := context.Background()
+	request := &dto.UpdateEmployeeBodyRequest{Name: "John Doe", Position: "Lead Engineer", Salary: 60000}
+
+	_, err := uc.UpdateEmployee(ctx, 1, request, dto.Precondition{})
+	assert.Equal(t, apperror.KindPreconditionRequired, apperror.KindOf(err))
+
+	_, err = uc.UpdateEmployee(ctx, 1, request, dto.Precondition{IfMatch: []string{"stale"}})
+	assert.Equal(t, apperror.KindPreconditionFailed, apperror.KindOf(err))
+	assert.Equal(t, "Developer", repo.employees[1].Position)
+
+	version := (&dto.Employee{UpdatedAt: updatedAt}).Version()
+	employee, err := uc.UpdateEmployee(ctx, 1, request, dto.Precondition{IfMatch: []string{"stale", version}})
+	assert.NoError(t, err)
+	assert.NotEqual(t, version, employee.Version())
+
+	err = uc.DeleteEmployee(ctx, 1, dto.Precondition{IfMatch: []string{version}})
+	assert.Equal(t, apperror.KindPreconditionFailed, apperror.KindOf(err))
+
+	err = uc.DeleteEmployee(ctx, 1, dto.Precondition{IfMatch: []string{"*"}})
+	assert.NoError(t, err)
+	assert.NotNil(t, repo.employees[1].DeletedAt)
+}
 
-	employeeID := 1
+func TestDeleteEmployee(t *testing.T) {
+	repo := newFakeEmployeeRepository(&dto.Employee{ID: 1, Name: "John Doe", Position: "Developer", Salary: 60000})
+	uc := NewEmployeeUsecase(repo, newFakeAuditRepository(), newFakeCompensationRepository(), newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+	ctx := context.Background()
+
+	err := uc.DeleteEmployee(ctx, 1, dto.Precondition{})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, repo.employees[1].DeletedAt)
+	_, err = uc.GetEmployeeById(ctx, 1)
+	assert.Equal(t, apperror.KindNotFound, apperror.KindOf(err))
+}
+
+func TestRestoreEmployee(t *testing.T) {
+	repo := newFakeEmployeeRepository(
+		&dto.Employee{ID: 1, Name: "John Doe", Position: "Developer", Salary: 60000},
+		&dto.Employee{ID: 2, Name: "Jane Smith", Position: "Manager", Salary: 80000},
+	)
+	uc := NewEmployeeUsecase(repo, newFakeAuditRepository(), newFakeCompensationRepository(), newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+	ctx := context.Background()
+	assert.NoError(t, uc.DeleteEmployee(ctx, 1, dto.Precondition{}))
 
-	mock.ExpectBegin()
-	mock.ExpectQuery(regexp.QuoteMeta(`select * from "employee" where "id"=$1`)).
-		WithArgs(employeeID).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "position", "salary", "created_at", "updated_at"}).
-			AddRow(employeeID, "John Doe", "Developer", 60000, time.Now(), time.Now()))
-	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "employee" WHERE (id = $1)`)).
-		WithArgs(employeeID).
-		WillReturnResult(sqlmock.NewResult(1, 1))
-	mock.ExpectCommit()
+	page, err := uc.GetAllEmployee(ctx, &dto.GetEmployee{OnlyDeleted: true})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), page.TotalCount)
+	assert.Equal(t, "John Doe", page.Employees[0].Name)
 
-	uc := NewEmployeeUsecase(db)
-	ctx := &gin.Context{}
+	page, err = uc.GetAllEmployee(ctx, &dto.GetEmployee{IncludeDeleted: true})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), page.TotalCount)
 
-	err = uc.DeleteEmployee(ctx, employeeID)
+	employee, err := uc.RestoreEmployee(ctx, 1, dto.Precondition{})
+	assert.NoError(t, err)
+	assert.Nil(t, employee.DeletedAt)
+
+	_, err = uc.RestoreEmployee(ctx, 1, dto.Precondition{})
+	assert.Equal(t, apperror.KindNotFound, apperror.KindOf(err))
+
+	_, err = uc.GetAllEmployee(ctx, &dto.GetEmployee{IncludeDeleted: true, OnlyDeleted: true})
+	assert.Equal(t, apperror.KindValidation, apperror.KindOf(err))
+}
+
+func TestRestoreEmployeePrecondition(t *testing.T) {
+	repo := newFakeEmployeeRepository(&dto.Employee{ID: 1, Name: "John Doe", Position: "Developer", Salary: 60000})
+	uc := NewEmployeeUsecase(repo, newFakeAuditRepository(), newFakeCompensationRepository(), newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{RequirePrecondition: true})
+	ctx := context.Background()
+	before, err := uc.GetEmployeeById(ctx, 1)
+	assert.NoError(t, err)
+	assert.NoError(t, uc.DeleteEmployee(ctx, 1, dto.Precondition{IfMatch: []string{before.Version()}}))
+	// keep the restore from landing in the same microsecond
+	repo.employees[1].UpdatedAt = repo.employees[1].UpdatedAt.Add(-time.Second)
+	deleted := repo.employees[1].Version()
+	assert.NotEqual(t, before.Version(), deleted)
+
+	_, err = uc.RestoreEmployee(ctx, 1, dto.Precondition{})
+	assert.Equal(t, apperror.KindPreconditionRequired, apperror.KindOf(err))
+	_, err = uc.RestoreEmployee(ctx, 1, dto.Precondition{IfMatch: []string{before.Version()}})
+	assert.Equal(t, apperror.KindPreconditionFailed, apperror.KindOf(err))
+
+	employee, err := uc.RestoreEmployee(ctx, 1, dto.Precondition{IfMatch: []string{deleted}})
+	assert.NoError(t, err)
+	assert.Nil(t, employee.DeletedAt)
+	assert.NotEqual(t, before.Version(), employee.Version())
+	assert.NotEqual(t, deleted, employee.Version())
+}
+
+func TestPurgeEmployee(t *testing.T) {
+	repo := newFakeEmployeeRepository(&dto.Employee{ID: 1, Name: "John Doe", Position: "Developer", Salary: 60000})
+	uc := NewEmployeeUsecase(repo, newFakeAuditRepository(), newFakeCompensationRepository(), newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+	ctx := context.Background()
+
+	err := uc.PurgeEmployee(ctx, 1)
+
+	assert.NoError(t, err)
+	assert.Empty(t, repo.employees)
+}
+
+func TestDeleteEmployeeNotFound(t *testing.T) {
+	uc := NewEmployeeUsecase(newFakeEmployeeRepository(), newFakeAuditRepository(), newFakeCompensationRepository(), newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+	ctx := context.Background()
+
+	err := uc.DeleteEmployee(ctx, 1, dto.Precondition{})
+
+	assert.Equal(t, apperror.KindNotFound, apperror.KindOf(err))
+}
+
+func TestCreateEmployeeValidates(t *testing.T) {
+	repo := newFakeEmployeeRepository()
+	uc := NewEmployeeUsecase(repo, newFakeAuditRepository(), newFakeCompensationRepository(), newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+	ctx := context.Background()
+
+	_, err := uc.CreateEmployee(ctx, &dto.EmployeeCreateRequest{Name: " ", Position: "Developer", Salary: 0})
+
+	assert.Equal(t, apperror.KindValidation, apperror.KindOf(err))
+	assert.Empty(t, repo.employees)
+}
 
+func TestGetEmployeeHistory(t *testing.T) {
+	audits := newFakeAuditRepository(
+		&dto.EmployeeAudit{ID: 1, EmployeeID: 1, Operation: dto.AuditCreate},
+		&dto.EmployeeAudit{ID: 2, EmployeeID: 2, Operation: dto.AuditCreate},
+		&dto.EmployeeAudit{ID: 3, EmployeeID: 1, Operation: dto.AuditUpdate},
+		&dto.EmployeeAudit{ID: 4, EmployeeID: 1, Operation: dto.AuditPurge},
+	)
+	repo := newFakeEmployeeRepository(&dto.Employee{ID: 5, Name: "John Doe", Position: "Developer", Salary: 60000})
+	uc := NewEmployeeUsecase(repo, audits, newFakeCompensationRepository(), newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+	ctx := context.Background()
+
+	page, err := uc.GetEmployeeHistory(ctx, 1, &dto.GetEmployeeHistory{Page: 1, PageSize: 2})
 	assert.NoError(t, err)
-	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, int64(3), page.TotalCount)
+	assert.Equal(t, 2, page.TotalPages)
+	assert.Equal(t, []int64{4, 3}, []int64{page.Entries[0].ID, page.Entries[1].ID})
+
+	page, err = uc.GetEmployeeHistory(ctx, 5, &dto.GetEmployeeHistory{})
+	assert.NoError(t, err)
+	assert.Empty(t, page.Entries)
+
+	_, err = uc.GetEmployeeHistory(ctx, 6, &dto.GetEmployeeHistory{})
+	assert.Equal(t, apperror.KindNotFound, apperror.KindOf(err))
+}
+
+func TestCreateEmployeeRecordsHireCompensation(t *testing.T) {
+	compensations := newFakeCompensationRepository()
+	uc := NewEmployeeUsecase(newFakeEmployeeRepository(), newFakeAuditRepository(), compensations, newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{DefaultCurrency: "EUR"})
+	ctx := context.Background()
+
+	response, err := uc.CreateEmployee(ctx, &dto.EmployeeCreateRequest{Name: "John Doe", Position: "Developer", Salary: 60000})
+
+	assert.NoError(t, err)
+	assert.Len(t, compensations.compensations, 1)
+	hire := compensations.compensations[0]
+	assert.Equal(t, response.Id, hire.EmployeeID)
+	assert.Equal(t, 60000.0, hire.Amount)
+	assert.Equal(t, "EUR", hire.Currency)
+	assert.Equal(t, dto.ReasonHire, hire.Reason)
+	assert.Equal(t, today(), hire.EffectiveFrom)
+}
+
+func TestScheduleCompensation(t *testing.T) {
+	hired := today().AddDate(-1, 0, 0)
+	repo := newFakeEmployeeRepository(&dto.Employee{ID: 1, Name: "John Doe", Position: "Developer", Salary: 60000})
+	compensations := newFakeCompensationRepository(&dto.Compensation{
+		ID: 1, EmployeeID: 1, Amount: 60000, Currency: "EUR", Reason: dto.ReasonHire, EffectiveFrom: hired,
+	})
+	uc := NewEmployeeUsecase(repo, newFakeAuditRepository(), compensations, newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+	ctx := context.Background()
+	raise := today().AddDate(0, 1, 0)
+
+	compensation, err := uc.ScheduleCompensation(ctx, 1, &dto.ScheduleCompensationRequest{
+		Amount:        66000,
+		Reason:        dto.ReasonMerit,
+		EffectiveFrom: raise.Format(dto.DateLayout),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "EUR", compensation.Currency)
+	assert.Nil(t, compensation.EffectiveTo)
+	assert.False(t, compensation.Active)
+
+	timeline, err := uc.GetCompensationTimeline(ctx, 1)
+
+	assert.NoError(t, err)
+	assert.Len(t, timeline, 2)
+	assert.Equal(t, raise, *timeline[0].EffectiveTo)
+	assert.True(t, timeline[0].Active)
+	assert.False(t, timeline[1].Active)
+	assert.True(t, repo.employees[1].UpdatedAt.IsZero(), "a later change leaves the employee alone")
+}
+
+func TestScheduleCompensationEffectiveToday(t *testing.T) {
+	repo := newFakeEmployeeRepository(&dto.Employee{ID: 1, Name: "John Doe", Position: "Developer", Salary: 60000})
+	compensations := newFakeCompensationRepository(&dto.Compensation{
+		ID: 1, EmployeeID: 1, Amount: 60000, Currency: "EUR", Reason: dto.ReasonHire, EffectiveFrom: today().AddDate(-1, 0, 0),
+	})
+	uc := NewEmployeeUsecase(repo, newFakeAuditRepository(), compensations, newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+	ctx := context.Background()
+	before, err := uc.GetEmployeeById(ctx, 1)
+	assert.NoError(t, err)
+
+	compensation, err := uc.ScheduleCompensation(ctx, 1, &dto.ScheduleCompensationRequest{
+		Amount:        66000,
+		Reason:        dto.ReasonMerit,
+		EffectiveFrom: today().Format(dto.DateLayout),
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, compensation.Active)
+	after, err := uc.GetEmployeeById(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 66000.0, after.Salary)
+	assert.NotEqual(t, before.Version(), after.Version())
+}
+
+func TestScheduleCompensationBeforeLaterChange(t *testing.T) {
+	repo := newFakeEmployeeRepository(&dto.Employee{ID: 1, Name: "John Doe", Position: "Developer", Salary: 60000})
+	later := today().AddDate(0, 2, 0)
+	compensations := newFakeCompensationRepository(
+		&dto.Compensation{ID: 1, EmployeeID: 1, Amount: 60000, Currency: "USD", Reason: dto.ReasonHire, EffectiveFrom: today(), EffectiveTo: &later},
+		&dto.Compensation{ID: 2, EmployeeID: 1, Amount: 70000, Currency: "USD", Reason: dto.ReasonPromotion, EffectiveFrom: later},
+	)
+	uc := NewEmployeeUsecase(repo, newFakeAuditRepository(), compensations, newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+	ctx := context.Background()
+	raise := today().AddDate(0, 1, 0)
+
+	compensation, err := uc.ScheduleCompensation(ctx, 1, &dto.ScheduleCompensationRequest{
+		Amount:        65000,
+		Currency:      "usd",
+		Reason:        dto.ReasonMerit,
+		EffectiveFrom: raise.Format(dto.DateLayout),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, later, *compensation.EffectiveTo)
+	assert.Equal(t, raise, *compensations.compensations[0].EffectiveTo)
+}
+
+func TestScheduleCompensationRejectsSameDay(t *testing.T) {
+	repo := newFakeEmployeeRepository(&dto.Employee{ID: 1, Name: "John Doe", Position: "Developer", Salary: 60000})
+	compensations := newFakeCompensationRepository(&dto.Compensation{
+		ID: 1, EmployeeID: 1, Amount: 60000, Currency: "USD", Reason: dto.ReasonHire, EffectiveFrom: today(),
+	})
+	uc := NewEmployeeUsecase(repo, newFakeAuditRepository(), compensations, newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+
+	_, err := uc.ScheduleCompensation(context.Background(), 1, &dto.ScheduleCompensationRequest{
+		Amount:        65000,
+		Reason:        dto.ReasonMerit,
+		EffectiveFrom: today().Format(dto.DateLayout),
+	})
+
+	appErr, _ := apperror.As(err)
+	assert.Equal(t, apperror.KindConflict, appErr.Kind)
+	assert.Equal(t, "compensation_already_exists", appErr.Code)
+}
+
+func TestScheduleCompensationRejectsPastDay(t *testing.T) {
+	repo := newFakeEmployeeRepository(&dto.Employee{ID: 1, Name: "John Doe", Position: "Developer", Salary: 60000})
+	uc := NewEmployeeUsecase(repo, newFakeAuditRepository(), newFakeCompensationRepository(), newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+
+	_, err := uc.ScheduleCompensation(context.Background(), 1, &dto.ScheduleCompensationRequest{
+		Amount:        65000,
+		Reason:        dto.ReasonMerit,
+		EffectiveFrom: today().AddDate(0, 0, -1).Format(dto.DateLayout),
+	})
+
+	appErr, _ := apperror.As(err)
+	assert.Equal(t, apperror.KindValidation, appErr.Kind)
+	assert.Equal(t, "/effective_from", appErr.Fields[0].Pointer)
+}
+
+func TestUpdateEmployeeSalaryRecordsAdjustment(t *testing.T) {
+	repo := newFakeEmployeeRepository(&dto.Employee{ID: 1, Name: "John Doe", Position: "Developer", Salary: 60000})
+	compensations := newFakeCompensationRepository(&dto.Compensation{
+		ID: 1, EmployeeID: 1, Amount: 60000, Currency: "EUR", Reason: dto.ReasonHire, EffectiveFrom: today().AddDate(0, -6, 0),
+	})
+	uc := NewEmployeeUsecase(repo, newFakeAuditRepository(), compensations, newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+	ctx := context.Background()
+
+	_, err := uc.UpdateEmployee(ctx, 1, &dto.UpdateEmployeeBodyRequest{Name: "John Doe", Position: "Developer", Salary: 62000}, dto.Precondition{})
+	assert.NoError(t, err)
+	_, err = uc.UpdateEmployee(ctx, 1, &dto.UpdateEmployeeBodyRequest{Name: "John Doe", Position: "Developer", Salary: 63000}, dto.Precondition{})
+	assert.NoError(t, err)
+
+	assert.Len(t, compensations.compensations, 2)
+	adjustment := compensations.compensations[1]
+	assert.Equal(t, 63000.0, adjustment.Amount)
+	assert.Equal(t, "EUR", adjustment.Currency)
+	assert.Equal(t, dto.ReasonAdjustment, adjustment.Reason)
+	assert.Equal(t, today(), *compensations.compensations[0].EffectiveTo)
+}
+
+func TestCreateEmployeeInUnknownDepartment(t *testing.T) {
+	repo := newFakeEmployeeRepository()
+	uc := NewEmployeeUsecase(repo, newFakeAuditRepository(), newFakeCompensationRepository(), newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+	departmentID := 7
+
+	_, err := uc.CreateEmployee(context.Background(), &dto.EmployeeCreateRequest{
+		Name: "John Doe", Position: "Developer", Salary: 60000, DepartmentID: &departmentID,
+	})
+
+	appErr, _ := apperror.As(err)
+	assert.Equal(t, apperror.KindValidation, appErr.Kind)
+	assert.Equal(t, "/department_id", appErr.Fields[0].Pointer)
+	assert.Empty(t, repo.employees)
+}
+
+func TestPatchEmployeeDepartment(t *testing.T) {
+	departmentID := 2
+	repo := newFakeEmployeeRepository(&dto.Employee{ID: 1, Name: "John Doe", Position: "Developer", Salary: 60000, DepartmentID: &departmentID})
+	departments := newFakeDepartmentRepository(&dto.Department{ID: 2, Name: "Engineering"}, &dto.Department{ID: 3, Name: "Sales"})
+	repo.departments = departments.departments
+	uc := NewEmployeeUsecase(repo, newFakeAuditRepository(), newFakeCompensationRepository(), departments, newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+	ctx := context.Background()
+
+	employee, err := uc.PatchEmployee(ctx, 1, dto.EmployeePatch{Format: dto.PatchMerge, Document: []byte(`{"department_id":3}`)}, dto.Precondition{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, *employee.DepartmentID)
+	assert.Equal(t, &dto.Department{ID: 3, Name: "Sales"}, employee.Department)
+
+	employee, err = uc.PatchEmployee(ctx, 1, dto.EmployeePatch{Format: dto.PatchMerge, Document: []byte(`{"department_id":null}`)}, dto.Precondition{})
+
+	assert.NoError(t, err)
+	assert.Nil(t, employee.DepartmentID)
+	assert.Equal(t, "Developer", employee.Position)
+}
+
+func TestGetDepartmentEmployees(t *testing.T) {
+	engineering, sales := 1, 2
+	repo := newFakeEmployeeRepository(
+		&dto.Employee{ID: 1, Name: "John Doe", DepartmentID: &engineering},
+		&dto.Employee{ID: 2, Name: "Jane Doe", DepartmentID: &sales},
+		&dto.Employee{ID: 3, Name: "Jim Doe"},
+	)
+	departments := newFakeDepartmentRepository(&dto.Department{ID: engineering, Name: "Engineering"}, &dto.Department{ID: sales, Name: "Sales"})
+	uc := NewEmployeeUsecase(repo, newFakeAuditRepository(), newFakeCompensationRepository(), departments, newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+	ctx := context.Background()
+
+	page, err := uc.GetDepartmentEmployees(ctx, sales, &dto.GetEmployee{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), page.TotalCount)
+	assert.Equal(t, 2, page.Employees[0].ID)
+	assert.Equal(t, []int{sales}, repo.lastQuery.Filter.DepartmentIDs)
+
+	_, err = uc.GetDepartmentEmployees(ctx, 9, &dto.GetEmployee{})
+
+	assert.Equal(t, apperror.KindNotFound, apperror.KindOf(err))
 }
@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+	"employee-management/domain/apperror"
+	"employee-management/domain/dto"
+	"employee-management/utils/validation"
+)
+
+// ExportEmployees streams the whole listing selected by the filters and
+// sort of request. An export is not paginated, so the page and keyset
+// parameters are rejected.
+func (uc *employeeUsecase) ExportEmployees(ctx context.Context, request *dto.GetEmployee, fn func(*dto.Employee) error) error {
+	if err := validation.Struct(request); err != nil {
+		return err
+	}
+
+	var fields []apperror.FieldError
+	if request.Keyset() || request.Page != 0 || request.PageSize != 0 {
+		fields = append(fields, apperror.FieldError{
+			Pointer: "/page",
+			Code:    "field_invalid",
+			Message: "an export is not paginated, page, page_size, cursor and limit are not allowed",
+		})
+	}
+	sortFields, sortErr := parseSort(request.Sort)
+	if sortErr != nil {
+		fields = append(fields, *sortErr)
+	}
+	fields = append(fields, employeeFilterProblems(request)...)
+	if len(fields) > 0 {
+		return apperror.ValidationFields("validation_failed", "request validation failed", fields)
+	}
+
+	query := dto.EmployeeListQuery{
+		Filter: toEmployeeFilter(request),
+		Sort:   sortFields,
+	}
+	if err := salaryQueryDenied(ctx, query); err != nil {
+		return err
+	}
+	return uc.repo.Stream(ctx, query, fn)
+}
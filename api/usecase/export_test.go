@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"employee-management/domain/apperror"
+	"employee-management/domain/dto"
+	"employee-management/utils/requestctx"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (r *fakeEmployeeRepository) Stream(ctx context.Context, query dto.EmployeeListQuery, fn func(*dto.Employee) error) error {
+	r.lastQuery = query
+	for id := 1; id < r.nextID; id++ {
+		if e, ok := r.employees[id]; ok && visible(e, query.Filter) {
+			if err := fn(e); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func TestExportEmployees(t *testing.T) {
+	engineering := 1
+	repo := newFakeEmployeeRepository(
+		&dto.Employee{ID: 1, Name: "John Doe", Position: "Developer", Salary: 60000, DepartmentID: &engineering},
+		&dto.Employee{ID: 2, Name: "Jane Smith", Position: "Manager", Salary: 80000},
+		&dto.Employee{ID: 3, Name: "Alice Johnson", Position: "Developer", Salary: 87000, DepartmentID: &engineering},
+	)
+	uc := NewEmployeeUsecase(repo, newFakeAuditRepository(), newFakeCompensationRepository(), newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+
+	var exported []*dto.Employee
+	err := uc.ExportEmployees(context.Background(), &dto.GetEmployee{DepartmentID: []int{engineering}, Sort: "-salary"}, func(e *dto.Employee) error {
+		exported = append(exported, e)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 3}, employeeIDs(exported))
+	assert.Equal(t, []int{engineering}, repo.lastQuery.Filter.DepartmentIDs)
+	assert.Equal(t, []dto.SortField{{Field: "salary", Desc: true}}, repo.lastQuery.Sort)
+	assert.Zero(t, repo.lastQuery.Limit)
+}
+
+func TestExportEmployeesStopsOnError(t *testing.T) {
+	repo := newFakeEmployeeRepository(
+		&dto.Employee{ID: 1, Name: "John Doe", Position: "Developer", Salary: 60000},
+		&dto.Employee{ID: 2, Name: "Jane Smith", Position: "Manager", Salary: 80000},
+	)
+	uc := NewEmployeeUsecase(repo, newFakeAuditRepository(), newFakeCompensationRepository(), newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+	errClosed := errors.New("connection closed")
+
+	calls := 0
+	err := uc.ExportEmployees(context.Background(), &dto.GetEmployee{}, func(e *dto.Employee) error {
+		calls++
+		return errClosed
+	})
+
+	assert.ErrorIs(t, err, errClosed)
+	assert.Equal(t, 1, calls)
+}
+
+func TestExportEmployeesRejectsPagination(t *testing.T) {
+	uc := NewEmployeeUsecase(newFakeEmployeeRepository(), newFakeAuditRepository(), newFakeCompensationRepository(), newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+
+	err := uc.ExportEmployees(context.Background(), &dto.GetEmployee{Page: 2, Sort: "password"}, func(e *dto.Employee) error {
+		return nil
+	})
+
+	appErr, ok := apperror.As(err)
+	assert.True(t, ok)
+	assert.Equal(t, apperror.KindValidation, appErr.Kind)
+	assert.Len(t, appErr.Fields, 2)
+	assert.Equal(t, "/page", appErr.Fields[0].Pointer)
+	assert.Equal(t, "/sort", appErr.Fields[1].Pointer)
+}
+
+func TestExportEmployeesDeniesSalaryQueries(t *testing.T) {
+	uc := NewEmployeeUsecase(newFakeEmployeeRepository(), newFakeAuditRepository(), newFakeCompensationRepository(), newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+	viewer := requestctx.WithPrincipal(context.Background(), &dto.Principal{Subject: "alice", Permissions: []string{dto.PermissionEmployeeRead}})
+
+	err := uc.ExportEmployees(viewer, &dto.GetEmployee{Sort: "-salary"}, func(e *dto.Employee) error {
+		return nil
+	})
+
+	assert.Equal(t, apperror.KindForbidden, apperror.KindOf(err))
+}
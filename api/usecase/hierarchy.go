@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"context"
+	"employee-management/domain/apperror"
+	"employee-management/domain/dto"
+)
+
+func (uc *employeeUsecase) GetDirectReports(ctx context.Context, employeeID int) ([]*dto.Employee, error) {
+	var reports []*dto.Employee
+	err := uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := uc.repo.FindByID(ctx, employeeID); err != nil {
+			return err
+		}
+		var err error
+		reports, err = uc.repo.DirectReports(ctx, employeeID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
+
+// GetReportingChain follows manager_id from the employee upwards. The chain
+// ends early at a manager that was deleted.
+func (uc *employeeUsecase) GetReportingChain(ctx context.Context, employeeID int) ([]*dto.Employee, error) {
+	var (
+		employee *dto.Employee
+		managers []*dto.Employee
+	)
+	err := uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if employee, err = uc.repo.FindByID(ctx, employeeID); err != nil {
+			return err
+		}
+		managers, err = uc.repo.Managers(ctx, employeeID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int]*dto.Employee, len(managers))
+	for _, manager := range managers {
+		byID[manager.ID] = manager
+	}
+	chain := make([]*dto.Employee, 0, len(managers))
+	for next := employee.ManagerID; next != nil; {
+		manager, ok := byID[*next]
+		if !ok {
+			break
+		}
+		// a manager is visited once, even if the data holds a cycle
+		delete(byID, manager.ID)
+		chain = append(chain, manager)
+		next = manager.ManagerID
+	}
+
+	return chain, nil
+}
+
+// GetOrgChart nests the subtree of an employee by manager_id. Reports keep
+// the name order of the subtree.
+func (uc *employeeUsecase) GetOrgChart(ctx context.Context, employeeID int) (*dto.OrgChartNode, error) {
+	employees, err := uc.repo.Subtree(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make(map[int]*dto.OrgChartNode, len(employees))
+	for _, employee := range employees {
+		nodes[employee.ID] = &dto.OrgChartNode{Employee: employee, Reports: []*dto.OrgChartNode{}}
+	}
+	root, ok := nodes[employeeID]
+	if !ok {
+		return nil, apperror.NotFound("employee_not_found", "employee not found")
+	}
+	for _, employee := range employees {
+		if employee.ID == employeeID || employee.ManagerID == nil {
+			continue
+		}
+		if manager, ok := nodes[*employee.ManagerID]; ok {
+			manager.Reports = append(manager.Reports, nodes[employee.ID])
+		}
+	}
+
+	return root, nil
+}
+
+// checkManager validates the manager an employee is about to report to.
+// employeeID is zero for an employee that does not exist yet and so cannot
+// have reports. The caller must hold the hierarchy lock. The manager stays
+// share locked, so that it cannot be deleted before the write commits.
+func (uc *employeeUsecase) checkManager(ctx context.Context, employeeID int, managerID *int) error {
+	if managerID == nil {
+		return nil
+	}
+	if *managerID == employeeID {
+		return invalidManager("an employee cannot manage itself")
+	}
+
+	_, err := uc.repo.LockSharedByID(ctx, *managerID)
+	if apperror.KindOf(err) == apperror.KindNotFound {
+		return invalidManager("manager_id does not reference an existing employee")
+	}
+	if err != nil || employeeID == 0 {
+		return err
+	}
+
+	managers, err := uc.repo.Managers(ctx, *managerID)
+	if err != nil {
+		return err
+	}
+	for _, manager := range managers {
+		if manager.ID == employeeID {
+			return invalidManager("manager_id reports to the employee, directly or indirectly")
+		}
+	}
+	return nil
+}
+
+// checkNoReports rejects deleting an employee who still manages someone.
+func (uc *employeeUsecase) checkNoReports(ctx context.Context, employeeID int) error {
+	reports, err := uc.repo.DirectReports(ctx, employeeID)
+	if err != nil {
+		return err
+	}
+	if len(reports) > 0 {
+		return apperror.Conflict("employee_has_reports", "employee still has direct reports, assign them to another manager first")
+	}
+	return nil
+}
+
+func invalidManager(message string) error {
+	return apperror.ValidationFields("validation_failed", "request validation failed", []apperror.FieldError{{
+		Pointer: "/manager_id",
+		Code:    "field_invalid",
+		Message: message,
+	}})
+}
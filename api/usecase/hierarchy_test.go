@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"context"
+	"employee-management/domain/apperror"
+	"employee-management/domain/dto"
+	"employee-management/domain/interfaces"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (r *fakeEmployeeRepository) LockHierarchy(ctx context.Context) error {
+	r.hierarchyLocks++
+	return nil
+}
+
+func (r *fakeEmployeeRepository) DirectReports(ctx context.Context, managerID int) ([]*dto.Employee, error) {
+	var reports []*dto.Employee
+	for _, e := range r.employees {
+		if e.DeletedAt == nil && e.ManagerID != nil && *e.ManagerID == managerID {
+			reports = append(reports, e)
+		}
+	}
+	sortByName(reports)
+	return reports, nil
+}
+
+func (r *fakeEmployeeRepository) Managers(ctx context.Context, employeeID int) ([]*dto.Employee, error) {
+	var (
+		managers []*dto.Employee
+		seen     = map[int]bool{}
+	)
+	for e := r.employees[employeeID]; e != nil && e.ManagerID != nil && !seen[*e.ManagerID]; {
+		seen[*e.ManagerID] = true
+		e = r.employees[*e.ManagerID]
+		if e != nil && e.DeletedAt == nil {
+			managers = append(managers, e)
+		}
+	}
+	return managers, nil
+}
+
+func (r *fakeEmployeeRepository) Subtree(ctx context.Context, employeeID int) ([]*dto.Employee, error) {
+	root, ok := r.employees[employeeID]
+	if !ok || root.DeletedAt != nil {
+		return nil, nil
+	}
+	subtree := []*dto.Employee{root}
+	for i := 0; i < len(subtree); i++ {
+		reports, _ := r.DirectReports(ctx, subtree[i].ID)
+		subtree = append(subtree, reports...)
+	}
+	sortByName(subtree)
+	return subtree, nil
+}
+
+func sortByName(employees []*dto.Employee) {
+	sort.Slice(employees, func(i, j int) bool { return employees[i].Name < employees[j].Name })
+}
+
+// newOrganization returns a repository holding
+//
+//	1 Ada
+//	├── 2 Bob
+//	│   └── 4 Dan
+//	└── 3 Cid
+func newOrganization() *fakeEmployeeRepository {
+	ada, bob := 1, 2
+	return newFakeEmployeeRepository(
+		&dto.Employee{ID: 1, Name: "Ada", Position: "CEO", Salary: 90000},
+		&dto.Employee{ID: 2, Name: "Bob", Position: "CTO", Salary: 80000, ManagerID: &ada},
+		&dto.Employee{ID: 3, Name: "Cid", Position: "CFO", Salary: 80000, ManagerID: &ada},
+		&dto.Employee{ID: 4, Name: "Dan", Position: "Developer", Salary: 60000, ManagerID: &bob},
+	)
+}
+
+func newHierarchyUsecase(repo *fakeEmployeeRepository) interfaces.EmployeeUsecase {
+	return NewEmployeeUsecase(repo, newFakeAuditRepository(), newFakeCompensationRepository(), newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+}
+
+func TestGetDirectReports(t *testing.T) {
+	uc := newHierarchyUsecase(newOrganization())
+
+	reports, err := uc.GetDirectReports(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 3}, employeeIDs(reports))
+
+	_, err = uc.GetDirectReports(context.Background(), 9)
+
+	assert.Equal(t, apperror.KindNotFound, apperror.KindOf(err))
+}
+
+func TestGetReportingChain(t *testing.T) {
+	uc := newHierarchyUsecase(newOrganization())
+
+	chain, err := uc.GetReportingChain(context.Background(), 4)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 1}, employeeIDs(chain))
+
+	chain, err = uc.GetReportingChain(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Empty(t, chain)
+}
+
+func TestGetOrgChart(t *testing.T) {
+	uc := newHierarchyUsecase(newOrganization())
+
+	chart, err := uc.GetOrgChart(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Ada", chart.Name)
+	assert.Len(t, chart.Reports, 2)
+	assert.Equal(t, "Bob", chart.Reports[0].Name)
+	assert.Equal(t, "Dan", chart.Reports[0].Reports[0].Name)
+	assert.Empty(t, chart.Reports[1].Reports)
+
+	_, err = uc.GetOrgChart(context.Background(), 9)
+
+	assert.Equal(t, apperror.KindNotFound, apperror.KindOf(err))
+}
+
+func TestChangeManagerRejectsCycles(t *testing.T) {
+	ctx := context.Background()
+	cases := []struct {
+		name       string
+		employeeID int
+		managerID  int
+	}{
+		{name: "self", employeeID: 2, managerID: 2},
+		{name: "direct report", employeeID: 2, managerID: 4},
+		{name: "indirect report", employeeID: 1, managerID: 4},
+		{name: "unknown", employeeID: 2, managerID: 9},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			uc := newHierarchyUsecase(newOrganization())
+			managerID := c.managerID
+
+			_, err := uc.UpdateEmployee(ctx, c.employeeID, &dto.UpdateEmployeeBodyRequest{
+				Name: "Someone", Position: "Developer", Salary: 60000, ManagerID: &managerID,
+			}, dto.Precondition{})
+
+			appErr, _ := apperror.As(err)
+			assert.Equal(t, apperror.KindValidation, appErr.Kind)
+			assert.Equal(t, "/manager_id", appErr.Fields[0].Pointer)
+		})
+	}
+}
+
+func TestChangeManager(t *testing.T) {
+	repo := newOrganization()
+	uc := newHierarchyUsecase(repo)
+
+	employee, err := uc.PatchEmployee(context.Background(), 4, dto.EmployeePatch{Format: dto.PatchMerge, Document: []byte(`{"manager_id":3}`)}, dto.Precondition{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, *employee.ManagerID)
+	assert.Equal(t, 3, *repo.employees[4].ManagerID)
+}
+
+func TestDeleteEmployeeWithReports(t *testing.T) {
+	repo := newOrganization()
+	uc := newHierarchyUsecase(repo)
+	ctx := context.Background()
+
+	err := uc.DeleteEmployee(ctx, 2, dto.Precondition{})
+
+	appErr, _ := apperror.As(err)
+	assert.Equal(t, apperror.KindConflict, appErr.Kind)
+	assert.Equal(t, "employee_has_reports", appErr.Code)
+
+	assert.NoError(t, uc.DeleteEmployee(ctx, 4, dto.Precondition{}))
+	assert.NoError(t, uc.DeleteEmployee(ctx, 2, dto.Precondition{}))
+	assert.Zero(t, repo.hierarchyLocks)
+}
+
+func TestPurgeEmployeeWithReports(t *testing.T) {
+	repo := newOrganization()
+	uc := newHierarchyUsecase(repo)
+	ctx := context.Background()
+
+	err := uc.PurgeEmployee(ctx, 2)
+
+	appErr, _ := apperror.As(err)
+	assert.Equal(t, apperror.KindConflict, appErr.Kind)
+	assert.Equal(t, "employee_has_reports", appErr.Code)
+
+	// a soft deleted report does not block the purge and loses its manager
+	assert.NoError(t, uc.DeleteEmployee(ctx, 4, dto.Precondition{}))
+	assert.NoError(t, uc.PurgeEmployee(ctx, 2))
+	assert.Nil(t, repo.employees[4].ManagerID)
+}
+
+func employeeIDs(employees []*dto.Employee) []int {
+	ids := make([]int, 0, len(employees))
+	for _, e := range employees {
+		ids = append(ids, e.ID)
+	}
+	return ids
+}
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"employee-management/domain/apperror"
+	"employee-management/domain/dto"
+	"employee-management/utils/requestctx"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// maxIdempotencyKeyLength is the longest idempotency key a client may send.
+const maxIdempotencyKeyLength = 255
+
+// idempotent runs fn within a transaction and returns the response fn
+// filled in. With a key, the first request made with it by the caller
+// runs fn and stores response; later requests with the key get the stored
+// response instead, and replayed is true. A key reused with a different
+// request is rejected.
+func (uc *employeeUsecase) idempotent(ctx context.Context, key string, request interface{}, now time.Time, response interface{}, fn func(ctx context.Context) error) (replayed bool, err error) {
+	if key == "" {
+		return false, uc.transactor.WithinTransaction(ctx, fn)
+	}
+	if len(key) > maxIdempotencyKeyLength {
+		return false, apperror.New(apperror.KindInvalidInput, "invalid_idempotency_key",
+			"the idempotency key must be at most 255 characters")
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return false, err
+	}
+	hash := sha256.Sum256(body)
+	claim := &dto.IdempotencyKey{
+		Key:         key,
+		RequestHash: hex.EncodeToString(hash[:]),
+		ExpiresAt:   now.Add(uc.opts.IdempotencyTTL),
+	}
+	if principal, ok := requestctx.Principal(ctx); ok {
+		claim.Scope = principal.Subject
+	}
+
+	err = uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		stored, err := uc.idempotencyKeys.Claim(ctx, claim)
+		if err != nil {
+			return err
+		}
+		if stored != nil {
+			if stored.RequestHash != claim.RequestHash {
+				return apperror.New(apperror.KindValidation, "idempotency_key_reused",
+					"the idempotency key was already used for a different request")
+			}
+			replayed = true
+			return json.Unmarshal(stored.Response, response)
+		}
+
+		if err := fn(ctx); err != nil {
+			return err
+		}
+		if claim.Response, err = json.Marshal(response); err != nil {
+			return err
+		}
+		return uc.idempotencyKeys.SaveResponse(ctx, claim)
+	})
+	return replayed, err
+}
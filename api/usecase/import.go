@@ -0,0 +1,328 @@
+package usecase
+
+import (
+	"context"
+	"employee-management/domain/apperror"
+	"employee-management/domain/dto"
+	"employee-management/utils/validation"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// importFields are the employee fields read from an import, in the order
+// their problems are reported.
+var importFields = []string{"name", "position", "salary", "department_id", "manager_id"}
+
+// requiredImportFields must have a column in every import.
+var requiredImportFields = map[string]bool{"name": true, "position": true, "salary": true}
+
+// errImportRolledBack ends the transaction of an import without committing
+// it.
+var errImportRolledBack = errors.New("import rolled back")
+
+// importRecord is a data row of an import and its line in the file.
+type importRecord struct {
+	row    int
+	values []string
+}
+
+// ImportEmployees creates an employee from every data row of a CSV file.
+// The file is read completely before anything is created, so a malformed
+// file, one with too many rows or one with salaries the caller may not set
+// fails as a whole. Rows are created like
+// CreateEmployee, each in a savepoint of one transaction that is committed
+// unless the import is a dry run or an all_or_nothing import with a failed
+// row.
+func (uc *employeeUsecase) ImportEmployees(ctx context.Context, file io.Reader, request *dto.ImportEmployeesRequest) (*dto.ImportReport, error) {
+	if err := validation.Struct(request); err != nil {
+		return nil, err
+	}
+	if request.Mode == "" {
+		request.Mode = dto.ImportAllOrNothing
+	}
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, apperror.Validation("csv_empty", "the CSV file has no header row")
+	}
+	if err != nil {
+		return nil, invalidCSV(err)
+	}
+	columns, err := newImportColumns(header, request.Mapping)
+	if err != nil {
+		return nil, err
+	}
+	// a file that sets salaries is refused as a whole rather than failing
+	// row by row, like the single-employee writes
+	if _, ok := columns.index["salary"]; ok {
+		if err := salaryWriteDenied(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	var records []importRecord
+	for {
+		values, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, invalidCSV(err)
+		}
+		if len(records) == uc.opts.MaxImportRows {
+			return nil, apperror.Validation("import_too_large", fmt.Sprintf("the CSV file may hold at most %d rows", uc.opts.MaxImportRows))
+		}
+		line, _ := reader.FieldPos(0)
+		records = append(records, importRecord{row: line, values: values})
+	}
+
+	report := &dto.ImportReport{
+		Mode:   request.Mode,
+		DryRun: request.DryRun,
+		Total:  len(records),
+		Rows:   make([]*dto.ImportRowResult, 0, len(records)),
+	}
+	err = uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, record := range records {
+			result, err := uc.importRow(ctx, columns, record.values)
+			if err != nil {
+				return err
+			}
+			result.Row = record.row
+			report.Rows = append(report.Rows, result)
+			if result.Status == dto.ImportRowFailed {
+				report.Failed++
+			}
+		}
+		if request.DryRun || (report.Failed > 0 && request.Mode == dto.ImportAllOrNothing) {
+			return errImportRolledBack
+		}
+		return nil
+	})
+	switch {
+	case errors.Is(err, errImportRolledBack):
+	case err != nil:
+		return nil, err
+	default:
+		report.Committed = true
+	}
+
+	for _, result := range report.Rows {
+		switch {
+		case result.Status != dto.ImportRowCreated:
+		case report.Committed:
+			report.Created++
+		case request.DryRun:
+			result.Status, result.ID = dto.ImportRowValid, nil
+		default:
+			result.Status, result.ID = dto.ImportRowSkipped, nil
+		}
+	}
+
+	return report, nil
+}
+
+// importRow creates the employee of one record. Problems with the record
+// are reported in the result; only failures of the import as a whole are
+// returned as errors.
+func (uc *employeeUsecase) importRow(ctx context.Context, columns *importColumns, values []string) (*dto.ImportRowResult, error) {
+	request, problems := columns.request(values)
+	if len(problems) == 0 {
+		response, err := uc.CreateEmployee(ctx, request)
+		if err == nil {
+			return &dto.ImportRowResult{Status: dto.ImportRowCreated, ID: &response.Id}, nil
+		}
+		appErr, ok := apperror.As(err)
+		if !ok || appErr.Kind == apperror.KindInternal {
+			return nil, err
+		}
+		problems = columns.rowErrors(appErr)
+	}
+
+	return &dto.ImportRowResult{Status: dto.ImportRowFailed, Errors: problems}, nil
+}
+
+// importColumns locates the employee fields in the records of a file.
+type importColumns struct {
+	header []string
+	// index maps a field to its column; fields without one are missing.
+	index map[string]int
+}
+
+// newImportColumns resolves mapping, a JSON object of field to header, and
+// the default header of every unmapped field against header.
+func newImportColumns(header []string, mapping string) (*importColumns, error) {
+	// spreadsheets often start UTF-8 files with a byte order mark
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+
+	headers := make(map[string]string)
+	if mapping != "" {
+		if err := json.Unmarshal([]byte(mapping), &headers); err != nil {
+			return nil, mappingError("field_invalid", "mapping must be a JSON object of field names to CSV headers")
+		}
+	}
+
+	var problems []apperror.FieldError
+	mapped := make([]string, 0, len(headers))
+	for field := range headers {
+		mapped = append(mapped, field)
+	}
+	sort.Strings(mapped)
+	for _, field := range mapped {
+		if !slices.Contains(importFields, field) {
+			problems = append(problems, apperror.FieldError{
+				Pointer: "/mapping",
+				Code:    "field_invalid",
+				Message: fmt.Sprintf("mapping field %q is not one of %s", field, strings.Join(importFields, ", ")),
+			})
+		}
+	}
+
+	columns := &importColumns{header: header, index: make(map[string]int)}
+	for _, field := range importFields {
+		name, ok := headers[field]
+		if !ok {
+			name = field
+		}
+		i := findHeader(header, name)
+		switch {
+		case i >= 0:
+			columns.index[field] = i
+		case ok:
+			problems = append(problems, apperror.FieldError{
+				Pointer: "/mapping",
+				Code:    "field_invalid",
+				Message: fmt.Sprintf("the CSV file has no column %q for %s", name, field),
+			})
+		case requiredImportFields[field]:
+			problems = append(problems, apperror.FieldError{
+				Pointer: "/mapping",
+				Code:    "field_required",
+				Message: fmt.Sprintf("the CSV file has no column for %s", field),
+			})
+		}
+	}
+	if len(problems) > 0 {
+		return nil, apperror.ValidationFields("validation_failed", "request validation failed", problems)
+	}
+
+	return columns, nil
+}
+
+// request builds the create request of a record, reporting values that
+// cannot be converted along with the fields that then fail validation.
+func (c *importColumns) request(values []string) (*dto.EmployeeCreateRequest, []*dto.ImportRowError) {
+	var problems []*dto.ImportRowError
+	request := &dto.EmployeeCreateRequest{
+		Name:     c.value(values, "name"),
+		Position: c.value(values, "position"),
+	}
+
+	if value := strings.TrimSpace(c.value(values, "salary")); value != "" {
+		salary, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsInf(salary, 0) || math.IsNaN(salary) {
+			problems = append(problems, c.rowError("salary", "field_invalid", "salary must be a number"))
+		} else {
+			request.Salary = salary
+		}
+	}
+	for _, field := range []string{"department_id", "manager_id"} {
+		value := strings.TrimSpace(c.value(values, field))
+		if value == "" {
+			continue
+		}
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			problems = append(problems, c.rowError(field, "field_invalid", field+" must be an integer"))
+			continue
+		}
+		if field == "department_id" {
+			request.DepartmentID = &id
+		} else {
+			request.ManagerID = &id
+		}
+	}
+	if len(problems) == 0 {
+		return request, nil
+	}
+
+	reported := make(map[string]bool)
+	for _, problem := range problems {
+		reported[problem.Field] = true
+	}
+	if appErr, ok := apperror.As(validation.Struct(request)); ok {
+		for _, problem := range c.rowErrors(appErr) {
+			if !reported[problem.Field] {
+				problems = append(problems, problem)
+			}
+		}
+	}
+	return request, problems
+}
+
+// rowErrors converts the error of a failed row into its report.
+func (c *importColumns) rowErrors(err *apperror.Error) []*dto.ImportRowError {
+	if len(err.Fields) == 0 {
+		return []*dto.ImportRowError{{Code: err.Code, Message: err.Message}}
+	}
+
+	problems := make([]*dto.ImportRowError, 0, len(err.Fields))
+	for _, field := range err.Fields {
+		problems = append(problems, c.rowError(strings.TrimPrefix(field.Pointer, "/"), field.Code, field.Message))
+	}
+	return problems
+}
+
+func (c *importColumns) rowError(field, code, message string) *dto.ImportRowError {
+	problem := &dto.ImportRowError{Field: field, Code: code, Message: message}
+	if i, ok := c.index[field]; ok {
+		problem.Column = c.header[i]
+	}
+	return problem
+}
+
+// value returns the value of field in values, or "" when the record is
+// too short or the file has no column for field.
+func (c *importColumns) value(values []string, field string) string {
+	i, ok := c.index[field]
+	if !ok || i >= len(values) {
+		return ""
+	}
+	return values[i]
+}
+
+// findHeader returns the column of the header matching name, ignoring case
+// and surrounding whitespace, or -1.
+func findHeader(header []string, name string) int {
+	name = strings.TrimSpace(name)
+	for i, h := range header {
+		if strings.EqualFold(strings.TrimSpace(h), name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func mappingError(code, message string) error {
+	return apperror.ValidationFields("validation_failed", "request validation failed", []apperror.FieldError{{
+		Pointer: "/mapping",
+		Code:    code,
+		Message: message,
+	}})
+}
+
+func invalidCSV(err error) error {
+	return apperror.Wrap(err, apperror.KindInvalidInput, "invalid_csv", err.Error())
+}
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"employee-management/domain/apperror"
+	"employee-management/domain/dto"
+	"employee-management/domain/interfaces"
+	"employee-management/utils/requestctx"
+	"maps"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rollbackTransactor undoes the employees created by a failing unit of
+// work, like a transaction or savepoint would.
+type rollbackTransactor struct {
+	repo *fakeEmployeeRepository
+}
+
+func (t rollbackTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	employees, nextID := maps.Clone(t.repo.employees), t.repo.nextID
+	if err := fn(ctx); err != nil {
+		t.repo.employees, t.repo.nextID = employees, nextID
+		return err
+	}
+	return nil
+}
+
+func newImportUsecase(repo *fakeEmployeeRepository, opts Options) interfaces.EmployeeUsecase {
+	return NewEmployeeUsecase(repo, newFakeAuditRepository(), newFakeCompensationRepository(), newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), rollbackTransactor{repo: repo}, opts)
+}
+
+const importCSV = "Full Name,Title,Annual Salary,Office\n" +
+	"John Doe,Developer,60000,Berlin\n" +
+	"Jane Doe,Manager,lots,Paris\n" +
+	"\"Jim\nDoe\",QA Engineer,50000,Rome\n"
+
+const importMapping = `{"name":"Full Name","position":"Title","salary":"annual salary"}`
+
+func TestImportEmployeesAllOrNothing(t *testing.T) {
+	repo := newFakeEmployeeRepository()
+	uc := newImportUsecase(repo, Options{})
+
+	report, err := uc.ImportEmployees(context.Background(), strings.NewReader(importCSV), &dto.ImportEmployeesRequest{Mapping: importMapping})
+
+	assert.NoError(t, err)
+	assert.Empty(t, repo.employees)
+	assert.False(t, report.Committed)
+	assert.Equal(t, dto.ImportAllOrNothing, report.Mode)
+	assert.Equal(t, 3, report.Total)
+	assert.Equal(t, 0, report.Created)
+	assert.Equal(t, 2, report.Failed)
+
+	assert.Equal(t, 2, report.Rows[0].Row)
+	assert.Equal(t, dto.ImportRowSkipped, report.Rows[0].Status)
+	assert.Nil(t, report.Rows[0].ID)
+
+	assert.Equal(t, 3, report.Rows[1].Row)
+	assert.Equal(t, dto.ImportRowFailed, report.Rows[1].Status)
+	assert.Equal(t, []*dto.ImportRowError{{Field: "salary", Column: "Annual Salary", Code: "field_invalid", Message: "salary must be a number"}}, report.Rows[1].Errors)
+
+	// the name of the last record spans two lines
+	assert.Equal(t, 4, report.Rows[2].Row)
+	assert.Equal(t, "name", report.Rows[2].Errors[0].Field)
+}
+
+func TestImportEmployeesBestEffort(t *testing.T) {
+	repo := newFakeEmployeeRepository()
+	uc := newImportUsecase(repo, Options{})
+	file := "name,position,salary,manager_id\n" +
+		"John Doe,Developer,60000,\n" +
+		"Jane Doe,Manager,70000,9\n"
+
+	report, err := uc.ImportEmployees(context.Background(), strings.NewReader(file), &dto.ImportEmployeesRequest{Mode: dto.ImportBestEffort})
+
+	assert.NoError(t, err)
+	assert.True(t, report.Committed)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, dto.ImportRowCreated, report.Rows[0].Status)
+	assert.Equal(t, 1, *report.Rows[0].ID)
+	assert.Equal(t, "John Doe", repo.employees[1].Name)
+	assert.Equal(t, dto.ImportRowFailed, report.Rows[1].Status)
+	assert.Equal(t, "manager_id", report.Rows[1].Errors[0].Field)
+	assert.Len(t, repo.employees, 1)
+}
+
+func TestImportEmployeesDryRun(t *testing.T) {
+	repo := newFakeEmployeeRepository()
+	uc := newImportUsecase(repo, Options{})
+	// a byte order mark and differently cased headers are accepted
+	file := "\ufeffName,POSITION,Salary\nJohn Doe,Developer,60000\n"
+
+	report, err := uc.ImportEmployees(context.Background(), strings.NewReader(file), &dto.ImportEmployeesRequest{Mode: dto.ImportBestEffort, DryRun: true})
+
+	assert.NoError(t, err)
+	assert.False(t, report.Committed)
+	assert.Equal(t, dto.ImportRowValid, report.Rows[0].Status)
+	assert.Empty(t, repo.employees)
+}
+
+func TestImportEmployeesRejectsFile(t *testing.T) {
+	cases := []struct {
+		name    string
+		file    string
+		mapping string
+		code    string
+	}{
+		{name: "empty", file: "", code: "csv_empty"},
+		{name: "missing column", file: "name,salary\nJohn Doe,60000\n", code: "field_required"},
+		{name: "unknown mapping field", file: "name,position,salary\n", mapping: `{"email":"Email"}`, code: "field_invalid"},
+		{name: "mapped header missing", file: "name,position,salary\n", mapping: `{"name":"Full Name"}`, code: "field_invalid"},
+		{name: "malformed", file: "name,position,salary\n\"John,Developer,60000\n", code: "invalid_csv"},
+		{name: "too many rows", file: "name,position,salary\nA,B,1\nC,D,2\nE,F,3\n", code: "import_too_large"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			repo := newFakeEmployeeRepository()
+			uc := newImportUsecase(repo, Options{MaxImportRows: 2})
+
+			_, err := uc.ImportEmployees(context.Background(), strings.NewReader(c.file), &dto.ImportEmployeesRequest{Mapping: c.mapping})
+
+			appErr, ok := apperror.As(err)
+			assert.True(t, ok)
+			code := appErr.Code
+			if len(appErr.Fields) > 0 {
+				code = appErr.Fields[0].Code
+			}
+			assert.Equal(t, c.code, code)
+			assert.Empty(t, repo.employees)
+		})
+	}
+}
+
+func TestImportEmployeesRequiresSalaryWrite(t *testing.T) {
+	repo := newFakeEmployeeRepository()
+	uc := newImportUsecase(repo, Options{})
+	clerk := requestctx.WithPrincipal(context.Background(), &dto.Principal{
+		Subject:     "alice",
+		Permissions: []string{dto.PermissionEmployeeRead, dto.PermissionEmployeeWrite},
+	})
+
+	report, err := uc.ImportEmployees(clerk, strings.NewReader(importCSV), &dto.ImportEmployeesRequest{Mapping: importMapping, Mode: dto.ImportBestEffort})
+
+	assert.Nil(t, report)
+	assert.Equal(t, apperror.KindForbidden, apperror.KindOf(err))
+	assert.Empty(t, repo.employees)
+}
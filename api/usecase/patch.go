@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"bytes"
+	"employee-management/domain/apperror"
+	"employee-management/domain/dto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+)
+
+// patchableFields are the members of the document a patch is applied to.
+var patchableFields = map[string]bool{"name": true, "position": true, "salary": true, "department_id": true, "manager_id": true}
+
+// applyPatch applies patch to the replaceable fields of employee. Members
+// removed by the patch, or set to null, come back as zero values and are
+// then rejected by validation as missing; a null department_id or
+// manager_id removes the employee from its department or reporting line.
+//
+// With hideSalary the document leaves the salary out, so that a patch
+// cannot reveal it; the salary is kept unless the patch sets it.
+func applyPatch(employee *dto.Employee, patch dto.EmployeePatch, hideSalary bool) (*dto.UpdateEmployeeBodyRequest, error) {
+	members := map[string]interface{}{
+		"name":          employee.Name,
+		"position":      employee.Position,
+		"salary":        employee.Salary,
+		"department_id": employee.DepartmentID,
+		"manager_id":    employee.ManagerID,
+	}
+	if hideSalary {
+		delete(members, "salary")
+	}
+	document, err := json.Marshal(members)
+	if err != nil {
+		return nil, err
+	}
+
+	var patched []byte
+	switch patch.Format {
+	case dto.PatchMerge:
+		patched, err = jsonpatch.MergePatch(document, patch.Document)
+		if err != nil {
+			return nil, apperror.Wrap(err, apperror.KindInvalidInput, "invalid_patch", "merge patch must be a JSON document")
+		}
+	case dto.PatchJSON:
+		operations, err := jsonpatch.DecodePatch(patch.Document)
+		if err != nil {
+			return nil, apperror.Wrap(err, apperror.KindInvalidInput, "invalid_patch", "JSON patch must be an array of operations")
+		}
+		if hideSalary {
+			if err := checkSalaryNotRead(operations); err != nil {
+				return nil, err
+			}
+		}
+		patched, err = operations.Apply(document)
+		switch {
+		case errors.Is(err, jsonpatch.ErrTestFailed):
+			return nil, apperror.Wrap(err, apperror.KindConflict, "patch_test_failed", "a test operation of the patch failed")
+		case err != nil:
+			return nil, apperror.Wrap(err, apperror.KindValidation, "patch_not_applicable", "the patch cannot be applied to the employee")
+		}
+	default:
+		return nil, apperror.New(apperror.KindUnsupportedMediaType, "unsupported_patch_format", "unsupported patch format")
+	}
+
+	request, err := decodePatched(patched)
+	if err != nil {
+		return nil, err
+	}
+	if hideSalary {
+		var members map[string]json.RawMessage
+		if err := json.Unmarshal(patched, &members); err != nil {
+			return nil, err
+		}
+		if _, ok := members["salary"]; !ok {
+			request.Salary = employee.Salary
+		}
+	}
+	return request, nil
+}
+
+// checkSalaryNotRead rejects the operations that read the salary: test,
+// and copy or move from it.
+func checkSalaryNotRead(operations jsonpatch.Patch) error {
+	for _, operation := range operations {
+		var (
+			path string
+			err  error
+		)
+		switch operation.Kind() {
+		case "test":
+			path, err = operation.Path()
+		case "copy", "move":
+			path, err = operation.From()
+		default:
+			continue
+		}
+		if err == nil && (path == "/salary" || strings.HasPrefix(path, "/salary/")) {
+			return apperror.Forbidden("permission_denied", "the salary:read permission is required to read the salary")
+		}
+	}
+	return nil
+}
+
+// decodePatched decodes a patched document, reporting members that cannot
+// be changed and members of the wrong type.
+func decodePatched(patched []byte) (*dto.UpdateEmployeeBodyRequest, error) {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(patched, &members); err != nil {
+		return nil, apperror.Validation("patch_not_applicable", "the patched employee must be a JSON object")
+	}
+
+	var fields []apperror.FieldError
+	names := make([]string, 0, len(members))
+	for name := range members {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !patchableFields[name] {
+			fields = append(fields, apperror.FieldError{
+				Pointer: "/" + name,
+				Code:    "field_read_only",
+				Message: fmt.Sprintf("%s cannot be changed", name),
+			})
+			continue
+		}
+		if bytes.Equal(members[name], []byte("null")) {
+			continue
+		}
+
+		var err error
+		switch name {
+		case "salary":
+			err = json.Unmarshal(members[name], new(float64))
+		case "department_id", "manager_id":
+			err = json.Unmarshal(members[name], new(int))
+		default:
+			err = json.Unmarshal(members[name], new(string))
+		}
+		if err != nil {
+			fields = append(fields, apperror.FieldError{
+				Pointer: "/" + name,
+				Code:    "field_invalid",
+				Message: fmt.Sprintf("%s has the wrong type", name),
+			})
+		}
+	}
+	if len(fields) > 0 {
+		return nil, apperror.ValidationFields("validation_failed", "request validation failed", fields)
+	}
+
+	request := new(dto.UpdateEmployeeBodyRequest)
+	if err := json.Unmarshal(patched, request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
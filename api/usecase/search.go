@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+	"employee-management/domain/apperror"
+	"employee-management/domain/dto"
+	"employee-management/utils/validation"
+	"strings"
+	"unicode"
+)
+
+const (
+	defaultSearchLimit = 20
+	// searchMinSimilarity lets "jon" find "John": they share half of the
+	// trigrams of "jon".
+	searchMinSimilarity = 0.4
+)
+
+// SearchEmployees matches the words of request.Q as prefixes of the words
+// of the name and position of employees, and the whole of request.Q by
+// trigram similarity, so that both "jon" and "jhon" find "John Doe".
+func (uc *employeeUsecase) SearchEmployees(ctx context.Context, request *dto.SearchEmployees) ([]*dto.EmployeeSearchResult, error) {
+	if err := validation.Struct(request); err != nil {
+		return nil, err
+	}
+
+	words := searchWords(request.Q)
+	if len(words) == 0 {
+		return nil, apperror.ValidationFields("validation_failed", "request validation failed", []apperror.FieldError{{
+			Pointer: "/q",
+			Code:    "field_invalid",
+			Message: "q must contain a letter or digit",
+		}})
+	}
+	if request.Limit == 0 {
+		request.Limit = defaultSearchLimit
+	}
+
+	var results []*dto.EmployeeSearchResult
+	err := uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		results, err = uc.repo.Search(ctx, dto.EmployeeSearchQuery{
+			Text:          request.Q,
+			Words:         words,
+			MinSimilarity: searchMinSimilarity,
+			Limit:         request.Limit,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// searchWords splits text into lower case words of letters and digits,
+// dropping everything else including the operators of tsquery.
+func searchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"employee-management/domain/apperror"
+	"employee-management/domain/dto"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Search of the fake matches names starting with any of the words.
+func (r *fakeEmployeeRepository) Search(ctx context.Context, query dto.EmployeeSearchQuery) ([]*dto.EmployeeSearchResult, error) {
+	r.lastSearchQuery = query
+	var results []*dto.EmployeeSearchResult
+	for id := 1; id < r.nextID; id++ {
+		e, ok := r.employees[id]
+		if !ok || e.DeletedAt != nil {
+			continue
+		}
+		for _, word := range query.Words {
+			if strings.HasPrefix(strings.ToLower(e.Name), word) {
+				results = append(results, &dto.EmployeeSearchResult{Employee: e, Score: 1, Rank: 1})
+				break
+			}
+		}
+	}
+	return results, nil
+}
+
+func TestSearchEmployees(t *testing.T) {
+	repo := newFakeEmployeeRepository(
+		&dto.Employee{ID: 1, Name: "John Doe", Position: "Developer", Salary: 60000},
+		&dto.Employee{ID: 2, Name: "Jane Smith", Position: "Manager", Salary: 80000},
+		&dto.Employee{ID: 3, Name: "Jonathan Price", Position: "QA", Salary: 50000},
+	)
+	uc := NewEmployeeUsecase(repo, newFakeAuditRepository(), newFakeCompensationRepository(), newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+
+	results, err := uc.SearchEmployees(context.Background(), &dto.SearchEmployees{Q: "  Jo & !dev:* "})
+
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, "John Doe", results[0].Name)
+	assert.Equal(t, "Jonathan Price", results[1].Name)
+	assert.Equal(t, dto.EmployeeSearchQuery{
+		Text:          "Jo & !dev:*",
+		Words:         []string{"jo", "dev"},
+		MinSimilarity: searchMinSimilarity,
+		Limit:         defaultSearchLimit,
+	}, repo.lastSearchQuery)
+}
+
+func TestSearchEmployeesRequiresWords(t *testing.T) {
+	uc := NewEmployeeUsecase(newFakeEmployeeRepository(), newFakeAuditRepository(), newFakeCompensationRepository(), newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+
+	_, err := uc.SearchEmployees(context.Background(), &dto.SearchEmployees{Q: "&|!"})
+
+	appErr, ok := apperror.As(err)
+	assert.True(t, ok)
+	assert.Equal(t, apperror.KindValidation, appErr.Kind)
+	assert.Equal(t, "/q", appErr.Fields[0].Pointer)
+
+	_, err = uc.SearchEmployees(context.Background(), &dto.SearchEmployees{Q: "   "})
+
+	appErr, _ = apperror.As(err)
+	assert.Equal(t, "field_required", appErr.Fields[0].Code)
+}
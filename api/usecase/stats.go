@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"employee-management/domain/apperror"
+	"employee-management/domain/dto"
+	"employee-management/utils/validation"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// defaultPercentiles are reported when a stats request asks for none.
+var defaultPercentiles = []float64{0.25, 0.75, 0.9}
+
+// GetEmployeeStats aggregates the current salaries of the employees
+// matching the filters of request. The aggregation runs in the database,
+// so the cost does not grow with the number of employees sent back.
+func (uc *employeeUsecase) GetEmployeeStats(ctx context.Context, request *dto.GetEmployee, stats *dto.GetEmployeeStats) (*dto.EmployeeStats, error) {
+	if err := validation.Struct(request); err != nil {
+		return nil, err
+	}
+	if err := validation.Struct(stats); err != nil {
+		return nil, err
+	}
+
+	var fields []apperror.FieldError
+	if request.Keyset() || request.Page != 0 || request.PageSize != 0 || request.Sort != "" {
+		fields = append(fields, apperror.FieldError{
+			Pointer: "/page",
+			Code:    "field_invalid",
+			Message: "stats are not paginated or sorted, page, page_size, cursor, limit and sort are not allowed",
+		})
+	}
+	groupBy, groupErr := parseGroupBy(stats.GroupBy)
+	if groupErr != nil {
+		fields = append(fields, *groupErr)
+	}
+	percentiles, percentileErr := parsePercentiles(stats.Percentiles)
+	if percentileErr != nil {
+		fields = append(fields, *percentileErr)
+	}
+	fields = append(fields, employeeFilterProblems(request)...)
+	if len(fields) > 0 {
+		return nil, apperror.ValidationFields("validation_failed", "request validation failed", fields)
+	}
+
+	groups, err := uc.repo.SalaryStats(ctx, dto.EmployeeStatsQuery{
+		Filter:      toEmployeeFilter(request),
+		GroupBy:     groupBy,
+		Percentiles: percentiles,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.EmployeeStats{GroupBy: groupBy, Groups: groups}, nil
+}
+
+// parseGroupBy parses a comma separated list of EmployeeGroupFields.
+func parseGroupBy(value string) ([]string, *apperror.FieldError) {
+	groupBy := make([]string, 0)
+	if value == "" {
+		return groupBy, nil
+	}
+
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimSpace(field)
+		if _, ok := dto.EmployeeGroupFields[field]; !ok {
+			return nil, &apperror.FieldError{
+				Pointer: "/group_by",
+				Code:    "field_invalid",
+				Message: fmt.Sprintf("group_by field %q is not one of %s", field, groupableFields()),
+			}
+		}
+		if slices.Contains(groupBy, field) {
+			return nil, &apperror.FieldError{
+				Pointer: "/group_by",
+				Code:    "field_invalid",
+				Message: fmt.Sprintf("group_by field %q is repeated", field),
+			}
+		}
+		groupBy = append(groupBy, field)
+	}
+
+	return groupBy, nil
+}
+
+// parsePercentiles parses fractions between 0 and 1, sorted and without
+// duplicates. It returns defaultPercentiles for an empty list.
+func parsePercentiles(values []string) ([]float64, *apperror.FieldError) {
+	if len(values) == 0 {
+		return append([]float64(nil), defaultPercentiles...), nil
+	}
+
+	percentiles := make([]float64, 0, len(values))
+	for _, value := range values {
+		percentile, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(percentile) || percentile < 0 || percentile > 1 {
+			return nil, &apperror.FieldError{
+				Pointer: "/percentiles",
+				Code:    "field_out_of_range",
+				Message: fmt.Sprintf("percentile %q must be a number between 0 and 1", value),
+			}
+		}
+		percentiles = append(percentiles, percentile)
+	}
+	slices.Sort(percentiles)
+
+	return slices.Compact(percentiles), nil
+}
+
+// groupableFields lists the fields stats can be grouped by.
+func groupableFields() string {
+	names := make([]string, 0, len(dto.EmployeeGroupFields))
+	for name := range dto.EmployeeGroupFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
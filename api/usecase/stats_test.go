@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"employee-management/domain/apperror"
+	"employee-management/domain/dto"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (r *fakeEmployeeRepository) SalaryStats(ctx context.Context, query dto.EmployeeStatsQuery) ([]*dto.EmployeeStatsGroup, error) {
+	r.lastStatsQuery = query
+	var headcount int64
+	for _, e := range r.employees {
+		if visible(e, query.Filter) {
+			headcount++
+		}
+	}
+	return []*dto.EmployeeStatsGroup{{Group: map[string]interface{}{}, Headcount: headcount}}, nil
+}
+
+func TestGetEmployeeStats(t *testing.T) {
+	repo := newFakeEmployeeRepository(
+		&dto.Employee{ID: 1, Name: "John Doe", Position: "Developer", Salary: 60000},
+		&dto.Employee{ID: 2, Name: "Jane Smith", Position: "Manager", Salary: 80000},
+	)
+	uc := NewEmployeeUsecase(repo, newFakeAuditRepository(), newFakeCompensationRepository(), newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+
+	stats, err := uc.GetEmployeeStats(context.Background(), &dto.GetEmployee{Position: []string{"Developer"}}, &dto.GetEmployeeStats{
+		GroupBy:     "position, department_id",
+		Percentiles: []string{"0.9,0.1", "0.9"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"position", "department_id"}, stats.GroupBy)
+	assert.Equal(t, int64(2), stats.Groups[0].Headcount)
+	assert.Equal(t, []string{"Developer"}, repo.lastStatsQuery.Filter.Positions)
+	assert.Equal(t, []string{"position", "department_id"}, repo.lastStatsQuery.GroupBy)
+	assert.Equal(t, []float64{0.1, 0.9}, repo.lastStatsQuery.Percentiles)
+}
+
+func TestGetEmployeeStatsDefaults(t *testing.T) {
+	repo := newFakeEmployeeRepository()
+	uc := NewEmployeeUsecase(repo, newFakeAuditRepository(), newFakeCompensationRepository(), newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+
+	stats, err := uc.GetEmployeeStats(context.Background(), &dto.GetEmployee{}, &dto.GetEmployeeStats{})
+
+	assert.NoError(t, err)
+	assert.Empty(t, stats.GroupBy)
+	assert.NotNil(t, stats.GroupBy)
+	assert.Equal(t, defaultPercentiles, repo.lastStatsQuery.Percentiles)
+}
+
+func TestGetEmployeeStatsRejectsInvalidQuery(t *testing.T) {
+	uc := NewEmployeeUsecase(newFakeEmployeeRepository(), newFakeAuditRepository(), newFakeCompensationRepository(), newFakeDepartmentRepository(), newFakeIdempotencyKeyRepository(), fakeTransactor{}, Options{})
+
+	_, err := uc.GetEmployeeStats(context.Background(), &dto.GetEmployee{Sort: "name"}, &dto.GetEmployeeStats{
+		GroupBy:     "position,salary",
+		Percentiles: []string{"1.5"},
+	})
+
+	appErr, ok := apperror.As(err)
+	assert.True(t, ok)
+	assert.Equal(t, apperror.KindValidation, appErr.Kind)
+	var pointers []string
+	for _, field := range appErr.Fields {
+		pointers = append(pointers, field.Pointer)
+	}
+	assert.Equal(t, []string{"/page", "/group_by", "/percentiles"}, pointers)
+}
@@ -4,8 +4,15 @@ import (
 	"employee-management/api/delivery/httphandler"
 	"employee-management/api/middleware"
 	"employee-management/api/middleware/swagger"
+	"employee-management/api/repository/postgres"
 	"employee-management/api/usecase"
+	"employee-management/config"
 	"employee-management/db"
+	"employee-management/domain/dto"
+	"employee-management/domain/interfaces"
+	"employee-management/utils/jwt"
+	"employee-management/utils/log"
+	"employee-management/utils/validation"
 	"fmt"
 	"os"
 	"time"
@@ -13,28 +20,61 @@ import (
 	"github.com/gin-contrib/gzip"
 	ginzap "github.com/gin-contrib/zap"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/volatiletech/sqlboiler/v4/boil"
 	"go.uber.org/zap"
 )
 
 func main() {
 
+	// load configuration from file, environment and flags
+	cfg, err := config.Load(os.Args[1:])
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "[ERROR] Failed to load config: %+v\n", err)
+		os.Exit(1)
+	}
+
+	logger, err := log.NewLogger(cfg.Log.Level)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "[ERROR] Failed to create logger: %+v\n", err)
+		os.Exit(1)
+	}
+	defer func() { _ = logger.Sync() }()
+
 	// connect to db
-	conn, err := db.Connect()
+	conn, err := db.Connect(cfg.Database)
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "[ERROR] Failed to connect to db: %+v\n", err)
+		logger.Fatal("failed to connect to db", zap.Error(err))
 	}
+	defer conn.Close()
+	boil.DebugMode = cfg.Database.Debug
+
+	logger.Info("connected to db",
+		zap.String("host", cfg.Database.Host),
+		zap.Int("port", cfg.Database.Port),
+		zap.String("dbname", cfg.Database.Name),
+	)
 
-	fmt.Println("connect", conn)
+	// validate request bodies with the shared rules
+	binding.Validator = validation.Gin()
 
 	// New gin server
 	r := gin.New()
+	if err := r.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		logger.Fatal("invalid trusted proxies", zap.Error(err))
+	}
+	forwardedProto, err := middleware.ForwardedProto(cfg.Server.TrustedProxies)
+	if err != nil {
+		logger.Fatal("invalid trusted proxies", zap.Error(err))
+	}
 
 	// inject middlewares
 	// newLogger
 	// recover
 	// swagger editor
 
-	logger, _ := zap.NewProduction()
+	r.Use(middleware.RequestID())
+	r.Use(forwardedProto)
 	r.Use(middleware.JSONMiddleware())
 
 	/*  Add a ginzap middleware, which:
@@ -44,19 +84,107 @@ func main() {
 	*/
 
 	// Host Swagger middleware
-	r.Use(gin.WrapH(swagger.Middleware()))
+	if cfg.Middleware.Swagger.Enabled {
+		r.Use(gin.WrapH(swagger.Middleware(cfg.Middleware.Swagger.SpecURL)))
+	}
 
 	r.Use(ginzap.Ginzap(logger, time.RFC3339, true))
 
 	/* Logs all panic to error log - stack means whether output the stack info. */
 	r.Use(ginzap.RecoveryWithZap(logger, true))
 
-	r.Use(gzip.Gzip(gzip.DefaultCompression))
+	if cfg.Middleware.Gzip.Enabled {
+		r.Use(gzip.Gzip(cfg.Middleware.Gzip.Level))
+	}
+
+	// limit every client address before authenticating it, so that failed
+	// authentications count too
+	routes := r.Group("/")
+	var rateLimitStore interfaces.RateLimitStore
+	if cfg.RateLimit.Enabled {
+		rateLimitStore = middleware.NewMemoryRateLimitStore()
+		if cfg.RateLimit.Store == "postgres" {
+			rateLimitStore = postgres.NewRateLimitStore(conn)
+		}
+		routes.Use(middleware.AddressRateLimit(rateLimitStore, rateLimit(cfg.RateLimit.Address)))
+	}
+
+	transactor := postgres.NewTransactor(conn)
+	opts := usecase.Options{
+		DefaultPageSize:     cfg.Pagination.DefaultPageSize,
+		MaxPageSize:         cfg.Pagination.MaxPageSize,
+		RequirePrecondition: cfg.Preconditions.Required,
+		DefaultCurrency:     cfg.Compensation.DefaultCurrency,
+		MaxImportRows:       cfg.Import.MaxRows,
+		IdempotencyTTL:      time.Duration(cfg.Idempotency.TTL),
+	}
+
+	// authenticate service clients by API key and, when enabled, require a
+	// bearer token from everyone else; the docs stay public
+	apiKeyUsecase := usecase.NewAPIKeyUsecase(postgres.NewAPIKeyRepository(conn), transactor, opts)
+	routes.Use(middleware.APIKeyAuthentication(apiKeyUsecase))
+	if cfg.Auth.Enabled {
+		keys, err := jwt.LoadKeys(cfg.Auth.HMACSecretFile, cfg.Auth.PublicKeyFiles, cfg.Auth.JWKSFile)
+		if err != nil {
+			logger.Fatal("failed to load token verification keys", zap.Error(err))
+		}
+		verifier := jwt.NewVerifier(keys, jwt.Options{
+			Issuer:   cfg.Auth.Issuer,
+			Audience: cfg.Auth.Audience,
+			Leeway:   time.Duration(cfg.Auth.Leeway),
+		})
+		policy, err := middleware.NewPolicy(cfg.Authorization.RolesClaim, cfg.Authorization.Roles)
+		if err != nil {
+			logger.Fatal("invalid authorization policy", zap.Error(err))
+		}
+		routes.Use(middleware.Authentication(verifier, policy))
+		logger.Info("bearer token authentication enabled", zap.Int("keys", len(keys)))
+	}
+
+	// limit the request rate of every client, told apart by principal or IP
+	if cfg.RateLimit.Enabled {
+		limits := middleware.RateLimitOptions{
+			Default: rateLimit(cfg.RateLimit.Default),
+			Routes:  make(map[string]dto.RateLimit, len(cfg.RateLimit.Routes)),
+		}
+		for route, rule := range cfg.RateLimit.Routes {
+			limits.Routes[route] = rateLimit(rule)
+		}
+		routes.Use(middleware.RateLimit(rateLimitStore, limits))
+	}
 
 	// employee endpoints
-	employeeUsecase := usecase.NewEmployeeUsecase(conn)
-	httphandler.NewEmployeeHandler(r, employeeUsecase)
+	employeeRepo := postgres.NewEmployeeRepository(conn)
+	postgres.RegisterEmployeeAuditHooks()
+	employeeAudits := postgres.NewEmployeeAuditRepository(conn)
+	compensations := postgres.NewCompensationRepository(conn)
+	departmentRepo := postgres.NewDepartmentRepository(conn)
+	idempotencyKeys := postgres.NewIdempotencyKeyRepository(conn)
+	employeeUsecase := usecase.NewEmployeeUsecase(employeeRepo, employeeAudits, compensations, departmentRepo, idempotencyKeys, transactor, opts)
+	httphandler.NewEmployeeHandler(routes, employeeUsecase)
+
+	// department endpoints
+	departmentUsecase := usecase.NewDepartmentUsecase(departmentRepo, employeeRepo, transactor, opts)
+	httphandler.NewDepartmentHandler(routes, departmentUsecase, employeeUsecase)
+	// the admin endpoints are for authenticated callers only: without
+	// bearer tokens anyone could purge employees or grant themselves every
+	// scope
+	if cfg.Admin.Enabled && cfg.Auth.Enabled {
+		httphandler.NewAdminHandler(routes, employeeUsecase)
+		httphandler.NewAPIKeyHandler(routes, apiKeyUsecase)
+	}
 
 	// Start the server
-	_ = r.Run(":8080")
+	logger.Info("starting server", zap.String("addr", cfg.Server.Addr))
+	if err := r.Run(cfg.Server.Addr); err != nil {
+		logger.Fatal("server stopped", zap.Error(err))
+	}
+}
+
+func rateLimit(rule config.RateLimitRule) dto.RateLimit {
+	return dto.RateLimit{
+		Requests: rule.Requests,
+		Period:   time.Duration(rule.Period),
+		Burst:    rule.Burst,
+	}
 }
@@ -0,0 +1,283 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Config holds every setting the server needs at start up.
+type Config struct {
+	Server        Server        `yaml:"server" toml:"server"`
+	Database      Database      `yaml:"database" toml:"database"`
+	Log           Log           `yaml:"log" toml:"log"`
+	Middleware    Middleware    `yaml:"middleware" toml:"middleware"`
+	Pagination    Pagination    `yaml:"pagination" toml:"pagination"`
+	Preconditions Preconditions `yaml:"preconditions" toml:"preconditions"`
+	Admin         Admin         `yaml:"admin" toml:"admin"`
+	Compensation  Compensation  `yaml:"compensation" toml:"compensation"`
+	Import        Import        `yaml:"import" toml:"import"`
+	Idempotency   Idempotency   `yaml:"idempotency" toml:"idempotency"`
+	Auth          Auth          `yaml:"auth" toml:"auth"`
+	Authorization Authorization `yaml:"authorization" toml:"authorization"`
+	RateLimit     RateLimit     `yaml:"rate_limit" toml:"rate_limit"`
+}
+
+// Server holds the HTTP listener settings.
+type Server struct {
+	Addr string `yaml:"addr" toml:"addr"`
+	// TrustedProxies are the addresses or CIDR ranges of the proxies whose
+	// X-Forwarded-For and X-Forwarded-Proto headers tell the client IP and
+	// scheme. By default no proxy is trusted: the client IP is the remote
+	// address and links use the scheme of the connection.
+	TrustedProxies []string `yaml:"trusted_proxies" toml:"trusted_proxies"`
+}
+
+// Database holds the postgres connection settings.
+type Database struct {
+	Host            string   `yaml:"host" toml:"host"`
+	Port            int      `yaml:"port" toml:"port"`
+	User            string   `yaml:"user" toml:"user"`
+	Password        string   `yaml:"password" toml:"password"`
+	Name            string   `yaml:"name" toml:"name"`
+	SSLMode         string   `yaml:"sslmode" toml:"sslmode"`
+	MaxOpenConns    int      `yaml:"max_open_conns" toml:"max_open_conns"`
+	MaxIdleConns    int      `yaml:"max_idle_conns" toml:"max_idle_conns"`
+	ConnMaxLifetime Duration `yaml:"conn_max_lifetime" toml:"conn_max_lifetime"`
+	Debug           bool     `yaml:"debug" toml:"debug"`
+}
+
+// Log holds the logger settings.
+type Log struct {
+	Level string `yaml:"level" toml:"level"`
+}
+
+// Middleware holds the settings of the optional gin middlewares.
+type Middleware struct {
+	Gzip    Gzip    `yaml:"gzip" toml:"gzip"`
+	Swagger Swagger `yaml:"swagger" toml:"swagger"`
+}
+
+// Pagination bounds the page sizes of list endpoints.
+type Pagination struct {
+	DefaultPageSize int `yaml:"default_page_size" toml:"default_page_size"`
+	MaxPageSize     int `yaml:"max_page_size" toml:"max_page_size"`
+}
+
+// Preconditions configures conditional writes. When Required is set,
+// updates and deletes without an If-Match header are rejected.
+type Preconditions struct {
+	Required bool `yaml:"required" toml:"required"`
+}
+
+// Admin configures the administrative endpoints, which are off by default
+// and only served when authentication is enabled.
+type Admin struct {
+	Enabled bool `yaml:"enabled" toml:"enabled"`
+}
+
+// Compensation configures the salary timeline of employees.
+type Compensation struct {
+	// DefaultCurrency is the ISO 4217 code of salaries set without one.
+	DefaultCurrency string `yaml:"default_currency" toml:"default_currency"`
+}
+
+// Import bounds the CSV employee import.
+type Import struct {
+	// MaxRows is the largest number of data rows a file may hold.
+	MaxRows int `yaml:"max_rows" toml:"max_rows"`
+}
+
+// Idempotency configures the Idempotency-Key header of employee creation.
+type Idempotency struct {
+	// TTL is how long a key and its response are kept for replays.
+	TTL Duration `yaml:"ttl" toml:"ttl"`
+}
+
+// Auth configures bearer token authentication, which is off by default.
+// Tokens are verified with the keys of every configured source.
+type Auth struct {
+	Enabled bool `yaml:"enabled" toml:"enabled"`
+	// Issuer and Audience, when set, must match the iss and aud claims.
+	Issuer   string `yaml:"issuer" toml:"issuer"`
+	Audience string `yaml:"audience" toml:"audience"`
+	// HMACSecretFile holds the HS256 secret.
+	HMACSecretFile string `yaml:"hmac_secret_file" toml:"hmac_secret_file"`
+	// PublicKeyFiles are PEM files of RS256 and ES256 public keys.
+	PublicKeyFiles []string `yaml:"public_key_files" toml:"public_key_files"`
+	// JWKSFile is a JSON Web Key Set document.
+	JWKSFile string `yaml:"jwks_file" toml:"jwks_file"`
+	// Leeway tolerates clock skew when checking exp and nbf.
+	Leeway Duration `yaml:"leeway" toml:"leeway"`
+}
+
+// Authorization maps the roles of authenticated callers onto permissions.
+// It only applies when auth is enabled.
+type Authorization struct {
+	// RolesClaim names the token claim listing the roles of the caller,
+	// as an array or a space separated string.
+	RolesClaim string `yaml:"roles_claim" toml:"roles_claim"`
+	// Roles lists the permissions granted by each role. Roles set in a
+	// config file replace the default role of the same name.
+	Roles map[string][]string `yaml:"roles" toml:"roles"`
+}
+
+// RateLimit configures the token bucket rate limiter, which is off by
+// default. Clients are told apart by their principal or IP address.
+type RateLimit struct {
+	Enabled bool `yaml:"enabled" toml:"enabled"`
+	// Store is memory, limiting every instance on its own, or postgres,
+	// sharing the limits between instances.
+	Store string `yaml:"store" toml:"store"`
+	// Default applies to every route without an override.
+	Default RateLimitRule `yaml:"default" toml:"default"`
+	// Routes overrides Default per route, keyed by method and path as
+	// registered, e.g. "GET /api/list_employee".
+	Routes map[string]RateLimitRule `yaml:"routes" toml:"routes"`
+	// Address limits every client address before it is authenticated, so
+	// that failed authentications are limited too.
+	Address RateLimitRule `yaml:"address" toml:"address"`
+}
+
+// RateLimitRule allows Requests per Period, in bursts of up to Burst
+// requests; a zero Burst means Requests.
+type RateLimitRule struct {
+	Requests int      `yaml:"requests" toml:"requests"`
+	Period   Duration `yaml:"period" toml:"period"`
+	Burst    int      `yaml:"burst" toml:"burst"`
+}
+
+// Gzip configures response compression.
+type Gzip struct {
+	Enabled bool `yaml:"enabled" toml:"enabled"`
+	Level   int  `yaml:"level" toml:"level"`
+}
+
+// Swagger configures the hosted API documentation.
+type Swagger struct {
+	Enabled bool   `yaml:"enabled" toml:"enabled"`
+	SpecURL string `yaml:"spec_url" toml:"spec_url"`
+}
+
+// Default returns the configuration used when nothing else is provided.
+// It deliberately carries no database password.
+func Default() Config {
+	return Config{
+		Server: Server{
+			Addr: ":8080",
+		},
+		Database: Database{
+			Host:            "localhost",
+			Port:            5432,
+			User:            "postgres",
+			Name:            "employee_management",
+			SSLMode:         "disable",
+			MaxOpenConns:    25,
+			MaxIdleConns:    25,
+			ConnMaxLifetime: Duration(5 * time.Minute),
+		},
+		Log: Log{
+			Level: "info",
+		},
+		Middleware: Middleware{
+			Gzip: Gzip{
+				Enabled: true,
+				Level:   -1,
+			},
+			Swagger: Swagger{
+				Enabled: true,
+				SpecURL: "/docs/swagger.yaml",
+			},
+		},
+		Pagination: Pagination{
+			DefaultPageSize: 100,
+			MaxPageSize:     1000,
+		},
+		Compensation: Compensation{
+			DefaultCurrency: "USD",
+		},
+		Import: Import{
+			MaxRows: 1000,
+		},
+		Idempotency: Idempotency{
+			TTL: Duration(24 * time.Hour),
+		},
+		Auth: Auth{
+			Leeway: Duration(30 * time.Second),
+		},
+		Authorization: Authorization{
+			RolesClaim: "roles",
+			Roles: map[string][]string{
+				"admin": {
+					"employee:read", "employee:write", "employee:purge", "salary:read", "salary:write",
+					"department:read", "department:write", "api_key:manage",
+				},
+				"hr": {
+					"employee:read", "employee:write", "salary:read", "salary:write",
+					"department:read", "department:write",
+				},
+				"manager": {"employee:read", "salary:read", "department:read"},
+				"viewer":  {"employee:read", "department:read"},
+			},
+		},
+		RateLimit: RateLimit{
+			Store: "memory",
+			Default: RateLimitRule{
+				Requests: 600,
+				Period:   Duration(time.Minute),
+				Burst:    100,
+			},
+			Address: RateLimitRule{
+				Requests: 1200,
+				Period:   Duration(time.Minute),
+				Burst:    200,
+			},
+		},
+	}
+}
+
+// DSN returns the lib/pq connection string for the database.
+func (d Database) DSN() string {
+	parts := []string{
+		"host=" + quoteDSN(d.Host),
+		fmt.Sprintf("port=%d", d.Port),
+		"user=" + quoteDSN(d.User),
+		"dbname=" + quoteDSN(d.Name),
+		"sslmode=" + quoteDSN(d.SSLMode),
+	}
+	if d.Password != "" {
+		parts = append(parts, "password="+quoteDSN(d.Password))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// quoteDSN quotes a conninfo value as described in the libpq documentation.
+func quoteDSN(v string) string {
+	if v != "" && !strings.ContainsAny(v, ` '\`) {
+		return v
+	}
+	v = strings.ReplaceAll(v, `\`, `\\`)
+	v = strings.ReplaceAll(v, `'`, `\'`)
+
+	return "'" + v + "'"
+}
+
+// Duration is a time.Duration that reads "5m" style values from config files.
+type Duration time.Duration
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Duration) UnmarshalText(text []byte) error {
+	v, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
@@ -0,0 +1,146 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadDefaults(t *testing.T) {
+	cfg, err := Load(nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, ":8080", cfg.Server.Addr)
+	assert.Equal(t, "localhost", cfg.Database.Host)
+	assert.Equal(t, 5432, cfg.Database.Port)
+	assert.Empty(t, cfg.Database.Password)
+	assert.Equal(t, "info", cfg.Log.Level)
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	path := writeFile(t, "config.yaml", `
+server:
+  addr: ":9000"
+database:
+  host: file-host
+  user: file-user
+  password: file-password
+  conn_max_lifetime: 1m
+log:
+  level: debug
+`)
+	t.Setenv("EMPLOYEE_CONFIG", path)
+	t.Setenv("EMPLOYEE_DB_HOST", "env-host")
+	t.Setenv("EMPLOYEE_DB_USER", "env-user")
+
+	cfg, err := Load([]string{"-db-user", "flag-user", "-gzip=false"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, ":9000", cfg.Server.Addr)
+	assert.Equal(t, "env-host", cfg.Database.Host)
+	assert.Equal(t, "flag-user", cfg.Database.User)
+	assert.Equal(t, "file-password", cfg.Database.Password)
+	assert.Equal(t, Duration(time.Minute), cfg.Database.ConnMaxLifetime)
+	assert.Equal(t, "debug", cfg.Log.Level)
+	assert.False(t, cfg.Middleware.Gzip.Enabled)
+}
+
+func TestLoadTOML(t *testing.T) {
+	path := writeFile(t, "config.toml", `
+[server]
+addr = ":7000"
+
+[database]
+port = 6543
+`)
+
+	cfg, err := Load([]string{"-config", path})
+
+	assert.NoError(t, err)
+	assert.Equal(t, ":7000", cfg.Server.Addr)
+	assert.Equal(t, 6543, cfg.Database.Port)
+}
+
+func TestLoadRejectsUnknownFileKeys(t *testing.T) {
+	path := writeFile(t, "config.yaml", "database:\n  hots: typo\n")
+
+	_, err := Load([]string{"-config", path})
+
+	assert.Error(t, err)
+}
+
+func TestLoadRejectsInvalidValues(t *testing.T) {
+	t.Setenv("EMPLOYEE_DB_PORT", "not-a-number")
+
+	_, err := Load(nil)
+
+	assert.ErrorContains(t, err, "EMPLOYEE_DB_PORT")
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	cfg := Default()
+	cfg.Database.Port = 0
+	cfg.Database.SSLMode = "sometimes"
+	cfg.Log.Level = "verbose"
+
+	err := cfg.Validate()
+
+	assert.ErrorContains(t, err, "database.port")
+	assert.ErrorContains(t, err, "database.sslmode")
+	assert.ErrorContains(t, err, "log.level")
+}
+
+func TestDSNQuotesValues(t *testing.T) {
+	d := Default().Database
+	d.Password = `it's secret`
+
+	assert.Equal(t,
+		`host=localhost port=5432 user=postgres dbname=employee_management sslmode=disable password='it\'s secret'`,
+		d.DSN())
+}
+
+func TestLoadAuth(t *testing.T) {
+	t.Setenv("EMPLOYEE_AUTH_ENABLED", "true")
+	t.Setenv("EMPLOYEE_AUTH_PUBLIC_KEY_FILES", "keys/a.pem, keys/b.pem,")
+
+	cfg, err := Load([]string{"-auth-leeway", "1m"})
+
+	assert.NoError(t, err)
+	assert.True(t, cfg.Auth.Enabled)
+	assert.Equal(t, []string{"keys/a.pem", "keys/b.pem"}, cfg.Auth.PublicKeyFiles)
+	assert.Equal(t, Duration(time.Minute), cfg.Auth.Leeway)
+}
+
+func TestValidateRequiresAuthKeys(t *testing.T) {
+	cfg := Default()
+	cfg.Auth.Enabled = true
+
+	assert.ErrorContains(t, cfg.Validate(), "auth needs")
+}
+
+func TestValidateRateLimitRoutes(t *testing.T) {
+	cfg := Default()
+	cfg.RateLimit.Store = "redis"
+	cfg.RateLimit.Routes = map[string]RateLimitRule{
+		"POST /api/add-employee": {Requests: 10, Period: Duration(time.Minute)},
+		"api/list_employee":      {Requests: 0, Period: Duration(time.Minute)},
+	}
+
+	err := cfg.Validate()
+
+	assert.ErrorContains(t, err, "rate_limit.store")
+	assert.ErrorContains(t, err, `rate_limit.routes key "api/list_employee" must be a method and a path`)
+	assert.ErrorContains(t, err, `rate_limit.routes["api/list_employee"].requests must be at least 1`)
+	assert.NotContains(t, err.Error(), "POST /api/add-employee")
+}
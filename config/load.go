@@ -0,0 +1,186 @@
+package config
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix is prepended to every environment variable read by Load.
+const EnvPrefix = "EMPLOYEE_"
+
+// option binds a single setting to its environment variable and flag.
+type option struct {
+	env   string
+	flag  string
+	usage string
+	field func(c *Config) interface{}
+}
+
+// options lists every setting that can be overridden from the environment
+// or the command line. Settings are applied in this order:
+// defaults, config file, environment, flags.
+var options = []option{
+	{"SERVER_ADDR", "addr", "HTTP listen address", func(c *Config) interface{} { return &c.Server.Addr }},
+	{"TRUSTED_PROXIES", "trusted-proxies", "comma separated addresses or CIDR ranges of trusted proxies", func(c *Config) interface{} { return &c.Server.TrustedProxies }},
+	{"DB_HOST", "db-host", "postgres host", func(c *Config) interface{} { return &c.Database.Host }},
+	{"DB_PORT", "db-port", "postgres port", func(c *Config) interface{} { return &c.Database.Port }},
+	{"DB_USER", "db-user", "postgres user", func(c *Config) interface{} { return &c.Database.User }},
+	{"DB_PASSWORD", "db-password", "postgres password", func(c *Config) interface{} { return &c.Database.Password }},
+	{"DB_NAME", "db-name", "postgres database name", func(c *Config) interface{} { return &c.Database.Name }},
+	{"DB_SSLMODE", "db-sslmode", "postgres sslmode", func(c *Config) interface{} { return &c.Database.SSLMode }},
+	{"DB_MAX_OPEN_CONNS", "db-max-open-conns", "maximum open database connections", func(c *Config) interface{} { return &c.Database.MaxOpenConns }},
+	{"DB_MAX_IDLE_CONNS", "db-max-idle-conns", "maximum idle database connections", func(c *Config) interface{} { return &c.Database.MaxIdleConns }},
+	{"DB_CONN_MAX_LIFETIME", "db-conn-max-lifetime", "maximum lifetime of a database connection", func(c *Config) interface{} { return &c.Database.ConnMaxLifetime }},
+	{"DB_DEBUG", "db-debug", "log every generated SQL query", func(c *Config) interface{} { return &c.Database.Debug }},
+	{"LOG_LEVEL", "log-level", "log level (debug, info, error)", func(c *Config) interface{} { return &c.Log.Level }},
+	{"GZIP_ENABLED", "gzip", "enable gzip response compression", func(c *Config) interface{} { return &c.Middleware.Gzip.Enabled }},
+	{"GZIP_LEVEL", "gzip-level", "gzip compression level (-1 to 9)", func(c *Config) interface{} { return &c.Middleware.Gzip.Level }},
+	{"SWAGGER_ENABLED", "swagger", "serve the API documentation", func(c *Config) interface{} { return &c.Middleware.Swagger.Enabled }},
+	{"PAGE_SIZE_DEFAULT", "page-size-default", "page size used when a listing does not ask for one", func(c *Config) interface{} { return &c.Pagination.DefaultPageSize }},
+	{"PAGE_SIZE_MAX", "page-size-max", "largest page size a listing may ask for", func(c *Config) interface{} { return &c.Pagination.MaxPageSize }},
+	{"PRECONDITION_REQUIRED", "precondition-required", "reject updates and deletes without If-Match", func(c *Config) interface{} { return &c.Preconditions.Required }},
+	{"ADMIN_ENABLED", "admin", "serve the administrative endpoints under api/admin", func(c *Config) interface{} { return &c.Admin.Enabled }},
+	{"COMPENSATION_DEFAULT_CURRENCY", "default-currency", "ISO 4217 currency of salaries set without one", func(c *Config) interface{} { return &c.Compensation.DefaultCurrency }},
+	{"IMPORT_MAX_ROWS", "import-max-rows", "largest number of rows a CSV import may hold", func(c *Config) interface{} { return &c.Import.MaxRows }},
+	{"IDEMPOTENCY_TTL", "idempotency-ttl", "how long idempotency keys are kept", func(c *Config) interface{} { return &c.Idempotency.TTL }},
+	{"AUTH_ENABLED", "auth", "require a bearer token on the API", func(c *Config) interface{} { return &c.Auth.Enabled }},
+	{"AUTH_ISSUER", "auth-issuer", "required iss claim of bearer tokens", func(c *Config) interface{} { return &c.Auth.Issuer }},
+	{"AUTH_AUDIENCE", "auth-audience", "required aud claim of bearer tokens", func(c *Config) interface{} { return &c.Auth.Audience }},
+	{"AUTH_HMAC_SECRET_FILE", "auth-hmac-secret-file", "file holding the HS256 secret", func(c *Config) interface{} { return &c.Auth.HMACSecretFile }},
+	{"AUTH_PUBLIC_KEY_FILES", "auth-public-key-files", "comma separated PEM files of RS256 and ES256 public keys", func(c *Config) interface{} { return &c.Auth.PublicKeyFiles }},
+	{"AUTH_JWKS_FILE", "auth-jwks-file", "JWKS file of token verification keys", func(c *Config) interface{} { return &c.Auth.JWKSFile }},
+	{"AUTH_LEEWAY", "auth-leeway", "clock skew tolerated on exp and nbf", func(c *Config) interface{} { return &c.Auth.Leeway }},
+	{"AUTH_ROLES_CLAIM", "auth-roles-claim", "token claim listing the roles of the caller", func(c *Config) interface{} { return &c.Authorization.RolesClaim }},
+	{"RATE_LIMIT_ENABLED", "rate-limit", "limit the request rate of every client", func(c *Config) interface{} { return &c.RateLimit.Enabled }},
+	{"RATE_LIMIT_STORE", "rate-limit-store", "where rate limits are kept (memory, postgres)", func(c *Config) interface{} { return &c.RateLimit.Store }},
+	{"RATE_LIMIT_REQUESTS", "rate-limit-requests", "requests a client may make per period", func(c *Config) interface{} { return &c.RateLimit.Default.Requests }},
+	{"RATE_LIMIT_PERIOD", "rate-limit-period", "period of the request rate limit", func(c *Config) interface{} { return &c.RateLimit.Default.Period }},
+	{"RATE_LIMIT_BURST", "rate-limit-burst", "requests a client may make at once", func(c *Config) interface{} { return &c.RateLimit.Default.Burst }},
+	{"RATE_LIMIT_ADDRESS_REQUESTS", "rate-limit-address-requests", "requests a client address may make per period, authenticated or not", func(c *Config) interface{} { return &c.RateLimit.Address.Requests }},
+	{"RATE_LIMIT_ADDRESS_PERIOD", "rate-limit-address-period", "period of the client address rate limit", func(c *Config) interface{} { return &c.RateLimit.Address.Period }},
+	{"RATE_LIMIT_ADDRESS_BURST", "rate-limit-address-burst", "requests a client address may make at once", func(c *Config) interface{} { return &c.RateLimit.Address.Burst }},
+	{"SWAGGER_SPEC_URL", "swagger-spec-url", "URL of the OpenAPI document", func(c *Config) interface{} { return &c.Middleware.Swagger.SpecURL }},
+}
+
+// Load builds the configuration from defaults, an optional YAML or TOML
+// file, environment variables and command line flags, in increasing order
+// of precedence. The file is taken from the -config flag or the
+// EMPLOYEE_CONFIG environment variable.
+func Load(args []string) (*Config, error) {
+	fs := flag.NewFlagSet("server", flag.ContinueOnError)
+	path := fs.String("config", os.Getenv(EnvPrefix+"CONFIG"), "path to a YAML or TOML config file")
+
+	flags := make(map[string]string)
+	for _, o := range options {
+		name, usage := o.flag, o.usage+" (env "+EnvPrefix+o.env+")"
+		record := func(v string) error {
+			flags[name] = v
+			return nil
+		}
+		if _, ok := o.field(&Config{}).(*bool); ok {
+			fs.BoolFunc(name, usage, record)
+		} else {
+			fs.Func(name, usage, record)
+		}
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	cfg := Default()
+	if *path != "" {
+		if err := loadFile(&cfg, *path); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, o := range options {
+		if v, ok := os.LookupEnv(EnvPrefix + o.env); ok {
+			if err := set(o.field(&cfg), v); err != nil {
+				return nil, errors.Wrapf(err, "invalid value for %s%s", EnvPrefix, o.env)
+			}
+		}
+	}
+
+	for _, o := range options {
+		if v, ok := flags[o.flag]; ok {
+			if err := set(o.field(&cfg), v); err != nil {
+				return nil, errors.Wrapf(err, "invalid value for -%s", o.flag)
+			}
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+func loadFile(cfg *Config, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return errors.Wrap(err, "failed to read config file")
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err = dec.Decode(cfg); errors.Is(err, io.EOF) {
+			err = nil
+		}
+	case ".toml":
+		dec := toml.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(cfg)
+	default:
+		return errors.Errorf("unsupported config file extension: %s", path)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse config file %s", path)
+	}
+
+	return nil
+}
+
+func set(field interface{}, v string) error {
+	switch f := field.(type) {
+	case *string:
+		*f = v
+	case *int:
+		i, err := strconv.Atoi(v)
+		if err != nil {
+			return err
+		}
+		*f = i
+	case *bool:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return err
+		}
+		*f = b
+	case *Duration:
+		return f.UnmarshalText([]byte(v))
+	case *[]string:
+		*f = nil
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				*f = append(*f, item)
+			}
+		}
+	default:
+		return errors.Errorf("unsupported config field type %T", field)
+	}
+
+	return nil
+}
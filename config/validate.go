@@ -0,0 +1,135 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var sslModes = map[string]bool{
+	"disable":     true,
+	"require":     true,
+	"verify-ca":   true,
+	"verify-full": true,
+}
+
+var logLevels = map[string]bool{
+	"debug": true,
+	"info":  true,
+	"error": true,
+}
+
+var rateLimitStores = map[string]bool{
+	"memory":   true,
+	"postgres": true,
+}
+
+var currencyCode = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// Validate reports every invalid setting at once.
+func (c *Config) Validate() error {
+	var problems []string
+
+	if c.Server.Addr == "" {
+		problems = append(problems, "server.addr must not be empty")
+	}
+
+	if c.Database.Host == "" {
+		problems = append(problems, "database.host must not be empty")
+	}
+	if c.Database.Port < 1 || c.Database.Port > 65535 {
+		problems = append(problems, "database.port must be between 1 and 65535")
+	}
+	if c.Database.User == "" {
+		problems = append(problems, "database.user must not be empty")
+	}
+	if c.Database.Name == "" {
+		problems = append(problems, "database.name must not be empty")
+	}
+	if !sslModes[c.Database.SSLMode] {
+		problems = append(problems, "database.sslmode must be one of disable, require, verify-ca, verify-full")
+	}
+	if c.Database.MaxOpenConns < 0 || c.Database.MaxIdleConns < 0 || c.Database.ConnMaxLifetime < 0 {
+		problems = append(problems, "database pool settings must not be negative")
+	}
+
+	if !logLevels[strings.ToLower(c.Log.Level)] {
+		problems = append(problems, "log.level must be one of debug, info, error")
+	}
+
+	if c.Middleware.Gzip.Level < -1 || c.Middleware.Gzip.Level > 9 {
+		problems = append(problems, "middleware.gzip.level must be between -1 and 9")
+	}
+	if c.Middleware.Swagger.Enabled && c.Middleware.Swagger.SpecURL == "" {
+		problems = append(problems, "middleware.swagger.spec_url must not be empty when swagger is enabled")
+	}
+
+	if c.Pagination.DefaultPageSize < 1 || c.Pagination.MaxPageSize < 1 {
+		problems = append(problems, "pagination page sizes must be at least 1")
+	} else if c.Pagination.DefaultPageSize > c.Pagination.MaxPageSize {
+		problems = append(problems, "pagination.default_page_size must not exceed pagination.max_page_size")
+	}
+
+	if !currencyCode.MatchString(c.Compensation.DefaultCurrency) {
+		problems = append(problems, "compensation.default_currency must be a three letter ISO 4217 code")
+	}
+
+	if c.Import.MaxRows < 1 {
+		problems = append(problems, "import.max_rows must be at least 1")
+	}
+
+	if c.Idempotency.TTL <= 0 {
+		problems = append(problems, "idempotency.ttl must be positive")
+	}
+
+	if c.Auth.Enabled && c.Auth.HMACSecretFile == "" && len(c.Auth.PublicKeyFiles) == 0 && c.Auth.JWKSFile == "" {
+		problems = append(problems, "auth needs hmac_secret_file, public_key_files or jwks_file when enabled")
+	}
+	if c.Auth.Leeway < 0 {
+		problems = append(problems, "auth.leeway must not be negative")
+	}
+	if c.Auth.Enabled && c.Authorization.RolesClaim == "" {
+		problems = append(problems, "authorization.roles_claim must not be empty when auth is enabled")
+	}
+
+	if !rateLimitStores[c.RateLimit.Store] {
+		problems = append(problems, "rate_limit.store must be one of memory, postgres")
+	}
+	problems = append(problems, c.RateLimit.Default.problems("rate_limit.default")...)
+	problems = append(problems, c.RateLimit.Address.problems("rate_limit.address")...)
+	routes := make([]string, 0, len(c.RateLimit.Routes))
+	for route := range c.RateLimit.Routes {
+		routes = append(routes, route)
+	}
+	sort.Strings(routes)
+	for _, route := range routes {
+		rule := c.RateLimit.Routes[route]
+		if method, path, ok := strings.Cut(route, " "); !ok || method == "" || !strings.HasPrefix(path, "/") {
+			problems = append(problems, fmt.Sprintf("rate_limit.routes key %q must be a method and a path, e.g. \"GET /api/list_employee\"", route))
+		}
+		problems = append(problems, rule.problems(fmt.Sprintf("rate_limit.routes[%q]", route))...)
+	}
+
+	if len(problems) > 0 {
+		return errors.Errorf("invalid configuration: %s", strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+func (r RateLimitRule) problems(name string) []string {
+	var problems []string
+	if r.Requests < 1 {
+		problems = append(problems, name+".requests must be at least 1")
+	}
+	if r.Period <= 0 {
+		problems = append(problems, name+".period must be positive")
+	}
+	if r.Burst < 0 {
+		problems = append(problems, name+".burst must not be negative")
+	}
+	return problems
+}
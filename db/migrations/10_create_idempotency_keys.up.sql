@@ -0,0 +1,14 @@
+-- Idempotency-Key headers of create requests with the response they got,
+-- scoped to the subject of the caller. response is only NULL while the
+-- request runs, within the transaction that claimed the key.
+CREATE TABLE idempotency_keys (
+  scope VARCHAR(255) NOT NULL,
+  key VARCHAR(255) NOT NULL,
+  request_hash CHAR(64) NOT NULL,
+  response JSONB,
+  created_at TIMESTAMP NOT NULL,
+  expires_at TIMESTAMP NOT NULL,
+  PRIMARY KEY (scope, key)
+);
+
+CREATE INDEX idempotency_keys_expires_at_idx ON idempotency_keys (expires_at);
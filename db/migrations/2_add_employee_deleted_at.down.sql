@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS employee_deleted_at_idx;
+
+ALTER TABLE employee DROP COLUMN IF EXISTS deleted_at;
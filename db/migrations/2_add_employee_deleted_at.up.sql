@@ -0,0 +1,3 @@
+ALTER TABLE employee ADD COLUMN deleted_at TIMESTAMP;
+
+CREATE INDEX employee_deleted_at_idx ON employee (deleted_at) WHERE deleted_at IS NOT NULL;
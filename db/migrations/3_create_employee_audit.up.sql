@@ -0,0 +1,12 @@
+-- employee_id has no foreign key: the history of a purged employee is kept.
+CREATE TABLE employee_audit (
+  id BIGSERIAL PRIMARY KEY,
+  employee_id INTEGER NOT NULL,
+  operation VARCHAR(16) NOT NULL,
+  actor VARCHAR(255) NOT NULL,
+  request_id VARCHAR(128),
+  changes JSONB NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX employee_audit_employee_id_idx ON employee_audit (employee_id, id);
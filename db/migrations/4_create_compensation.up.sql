@@ -0,0 +1,16 @@
+-- Effective-dated salaries. effective_to is exclusive and NULL for the
+-- latest record; the records of an employee form a gapless timeline.
+CREATE TABLE compensation (
+  id BIGSERIAL PRIMARY KEY,
+  employee_id INTEGER NOT NULL REFERENCES employee (id) ON DELETE CASCADE,
+  amount DOUBLE PRECISION NOT NULL CHECK (amount > 0),
+  currency CHAR(3) NOT NULL,
+  reason VARCHAR(32) NOT NULL,
+  effective_from DATE NOT NULL,
+  effective_to DATE CHECK (effective_to > effective_from),
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  UNIQUE (employee_id, effective_from)
+);
+
+INSERT INTO compensation (employee_id, amount, currency, reason, effective_from)
+SELECT id, salary, 'USD', 'hire', created_at::date FROM employee;
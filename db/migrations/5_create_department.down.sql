@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS employee_department_id_idx;
+
+ALTER TABLE employee DROP COLUMN IF EXISTS department_id;
+
+DROP TABLE IF EXISTS department;
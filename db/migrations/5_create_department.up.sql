@@ -0,0 +1,11 @@
+CREATE TABLE department (
+  id SERIAL PRIMARY KEY,
+  name VARCHAR(255) NOT NULL UNIQUE,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- A department cannot be dropped while employees, deleted or not, belong to it.
+ALTER TABLE employee ADD COLUMN department_id INTEGER REFERENCES department (id);
+
+CREATE INDEX employee_department_id_idx ON employee (department_id);
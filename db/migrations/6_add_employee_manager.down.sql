@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS employee_manager_id_idx;
+
+ALTER TABLE employee DROP COLUMN IF EXISTS manager_id;
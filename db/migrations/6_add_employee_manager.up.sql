@@ -0,0 +1,5 @@
+-- The reporting line. Cycles are rejected by the application; the check
+-- only guards against the shortest one.
+ALTER TABLE employee ADD COLUMN manager_id INTEGER REFERENCES employee (id) CHECK (manager_id <> id);
+
+CREATE INDEX employee_manager_id_idx ON employee (manager_id);
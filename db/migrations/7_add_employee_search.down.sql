@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS employee_position_trgm_idx;
+DROP INDEX IF EXISTS employee_name_trgm_idx;
+DROP INDEX IF EXISTS employee_search_idx;
+
+DROP EXTENSION IF EXISTS pg_trgm;
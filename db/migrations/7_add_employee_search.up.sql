@@ -0,0 +1,11 @@
+-- Search matches words by prefix through the full text index and
+-- misspelled or partial words through the trigram indexes. The expression
+-- of employee_search_idx must stay identical to the one searched.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX employee_search_idx ON employee USING GIN (
+  (setweight(to_tsvector('simple', name), 'A') || setweight(to_tsvector('simple', position), 'B'))
+);
+
+CREATE INDEX employee_name_trgm_idx ON employee USING GIN (name gin_trgm_ops);
+CREATE INDEX employee_position_trgm_idx ON employee USING GIN (position gin_trgm_ops);
@@ -0,0 +1,13 @@
+-- Keys of service clients. Only the SHA-256 hash of a key is stored;
+-- prefix holds its first characters to tell keys apart in listings.
+CREATE TABLE api_keys (
+  id BIGSERIAL PRIMARY KEY,
+  name VARCHAR(255) NOT NULL,
+  prefix VARCHAR(16) NOT NULL,
+  key_hash CHAR(64) NOT NULL UNIQUE,
+  scopes TEXT[] NOT NULL,
+  expires_at TIMESTAMP,
+  last_used_at TIMESTAMP,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  revoked_at TIMESTAMP
+);
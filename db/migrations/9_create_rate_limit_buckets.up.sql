@@ -0,0 +1,12 @@
+-- Token buckets of the rate limiter, shared by every instance of the
+-- server. Losing them on a crash only resets the limits, so the table is
+-- not logged. full_at is when a bucket is full again; from then on the row
+-- is equivalent to no row and may be removed.
+CREATE UNLOGGED TABLE rate_limit_buckets (
+  key VARCHAR(512) PRIMARY KEY,
+  tokens DOUBLE PRECISION NOT NULL,
+  updated_at TIMESTAMP NOT NULL,
+  full_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX rate_limit_buckets_full_at_idx ON rate_limit_buckets (full_at);
@@ -2,37 +2,28 @@ package db
 
 import (
 	"database/sql"
-	"fmt"
+	"employee-management/config"
+	"time"
 
 	_ "github.com/lib/pq"
+	"github.com/pkg/errors"
 )
 
-const (
-	host     = "localhost"
-	port     = 5432
-	user     = "postgres"
-	password = "pwd123"
-	dbname   = "employee_management"
-)
-
-func Connect() (*sql.DB, error) {
-	var err error
-
-	psqlInfo := fmt.Sprintf("host=%s port=%d user=%s "+
-		"password=%s dbname=%s sslmode=disable",
-		host, port, user, password, dbname)
-	db, err := sql.Open("postgres", psqlInfo)
+// Connect opens the postgres pool described by cfg and verifies it with a ping.
+func Connect(cfg config.Database) (*sql.DB, error) {
+	db, err := sql.Open("postgres", cfg.DSN())
 	if err != nil {
-		panic(err)
+		return nil, errors.Wrap(err, "failed to open database")
 	}
-	// defer db.Close()
 
-	err = db.Ping()
-	if err != nil {
-		panic(err)
-	}
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(time.Duration(cfg.ConnMaxLifetime))
 
-	fmt.Println("Successfully connected!")
+	if err := db.Ping(); err != nil {
+		_ = db.Close()
+		return nil, errors.Wrap(err, "failed to ping database")
+	}
 
 	return db, nil
 }
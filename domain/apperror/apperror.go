@@ -0,0 +1,118 @@
+package apperror
+
+import (
+	"errors"
+)
+
+// Kind classifies an error independently of the transport that reports it.
+type Kind int
+
+const (
+	// KindInternal is an unexpected failure.
+	KindInternal Kind = iota
+	// KindInvalidInput is a request that could not be decoded.
+	KindInvalidInput
+	// KindNotFound is a reference to a resource that does not exist.
+	KindNotFound
+	// KindConflict is a request that clashes with the current state.
+	KindConflict
+	// KindValidation is a well formed request that breaks a business rule.
+	KindValidation
+	// KindPreconditionFailed is a conditional request whose condition does not hold.
+	KindPreconditionFailed
+	// KindForbidden is a request the caller is not allowed to make.
+	KindForbidden
+	// KindUnsupportedMediaType is a request body in a format that is not accepted.
+	KindUnsupportedMediaType
+	// KindPreconditionRequired is a write that must be made conditional.
+	KindPreconditionRequired
+	// KindNotAcceptable is a request for a response format that is not offered.
+	KindNotAcceptable
+	// KindUnauthenticated is a request whose credentials are missing or invalid.
+	KindUnauthenticated
+)
+
+// Error is a domain error carrying a Kind and a machine-readable code.
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	Fields  []FieldError
+	Err     error
+}
+
+// FieldError describes a single invalid field of a request.
+type FieldError struct {
+	// Pointer is the RFC 6901 JSON pointer of the field, e.g. "/name".
+	Pointer string
+	Code    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// New returns an Error of the given kind.
+func New(kind Kind, code string, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+// Wrap returns an Error of the given kind caused by err.
+func Wrap(err error, kind Kind, code string, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message, Err: err}
+}
+
+// NotFound returns a KindNotFound error.
+func NotFound(code string, message string) *Error {
+	return New(KindNotFound, code, message)
+}
+
+// Conflict returns a KindConflict error.
+func Conflict(code string, message string) *Error {
+	return New(KindConflict, code, message)
+}
+
+// Validation returns a KindValidation error.
+func Validation(code string, message string) *Error {
+	return New(KindValidation, code, message)
+}
+
+// ValidationFields returns a KindValidation error listing every invalid field.
+func ValidationFields(code string, message string, fields []FieldError) *Error {
+	return &Error{Kind: KindValidation, Code: code, Message: message, Fields: fields}
+}
+
+// PreconditionFailed returns a KindPreconditionFailed error.
+func PreconditionFailed(code string, message string) *Error {
+	return New(KindPreconditionFailed, code, message)
+}
+
+// Forbidden returns a KindForbidden error.
+func Forbidden(code string, message string) *Error {
+	return New(KindForbidden, code, message)
+}
+
+// As returns the first *Error in err's chain.
+func As(err error) (*Error, bool) {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr, true
+	}
+	return nil, false
+}
+
+// KindOf returns the Kind of err, or KindInternal when err is not an *Error.
+func KindOf(err error) Kind {
+	if appErr, ok := As(err); ok {
+		return appErr.Kind
+	}
+	return KindInternal
+}
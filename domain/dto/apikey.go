@@ -0,0 +1,70 @@
+package dto
+
+import (
+	"strings"
+	"time"
+)
+
+// APIKeyPrefix starts every API key, so that leaked keys are easy to spot.
+const APIKeyPrefix = "emk_"
+
+// APIKey authenticates a service client, such as a cron job, without an
+// interactive login. The key itself is only known when it is created; the
+// server keeps its SHA-256 hash.
+type APIKey struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// Prefix is the start of the key, which tells keys apart in listings.
+	Prefix string `json:"prefix"`
+	Hash   string `json:"-"`
+	// Scopes are the permissions granted to the clients of the key.
+	Scopes []string `json:"scopes"`
+	// ExpiresAt is nil for keys that do not expire.
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+}
+
+// Active reports whether the key may be used at the given time.
+func (k *APIKey) Active(at time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || at.Before(*k.ExpiresAt))
+}
+
+// CreateAPIKeyRequest is the body of the create API key endpoint.
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" binding:"required,max=255,nocontrol"`
+	Scopes    []string   `json:"scopes" binding:"required,min=1,max=20"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// Normalize trims surrounding whitespace before validation.
+func (r *CreateAPIKeyRequest) Normalize() {
+	r.Name = strings.TrimSpace(r.Name)
+	for i, scope := range r.Scopes {
+		r.Scopes[i] = strings.TrimSpace(scope)
+	}
+}
+
+// CreatedAPIKey is a new API key along with its secret, which is shown
+// this one time only.
+type CreatedAPIKey struct {
+	*APIKey
+	Key string `json:"key"`
+}
+
+// GetAPIKeys holds the query parameters of the list API keys endpoint.
+type GetAPIKeys struct {
+	Page     int `json:"page" form:"page" binding:"gte=0"`
+	PageSize int `json:"page_size" form:"page_size" binding:"gte=0"`
+}
+
+// APIKeyPage is one page of API keys, newest first.
+type APIKeyPage struct {
+	PageInfo
+	Keys []*APIKey
+}
+
+type GetAPIKeyByIDRequest struct {
+	APIKeyID int64 `json:"api_key_id" uri:"api_key_id" binding:"required"`
+}
@@ -0,0 +1,47 @@
+package dto
+
+import "time"
+
+// Audit operations recorded for an employee.
+const (
+	AuditCreate  = "create"
+	AuditUpdate  = "update"
+	AuditDelete  = "delete"
+	AuditRestore = "restore"
+	AuditPurge   = "purge"
+)
+
+// EmployeeAudit is one recorded change of an employee.
+type EmployeeAudit struct {
+	// ID is zero for a scheduled salary change, which is derived from the
+	// compensation timeline on the day it takes effect.
+	ID         int64  `json:"id,omitempty"`
+	EmployeeID int    `json:"employee_id"`
+	Operation  string `json:"operation"`
+	// Actor is the subject of the caller, or "anonymous".
+	Actor     string `json:"actor"`
+	RequestID string `json:"request_id,omitempty"`
+	// Changes holds the changed fields by their JSON name.
+	Changes   map[string]FieldChange `json:"changes"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+// FieldChange is the value of a field before and after a change. From is
+// null for creates and To is null for purges.
+type FieldChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// GetEmployeeHistory holds the query parameters of the history endpoint.
+type GetEmployeeHistory struct {
+	Page     int `json:"page" form:"page" binding:"gte=0"`
+	PageSize int `json:"page_size" form:"page_size" binding:"gte=0"`
+}
+
+// EmployeeHistoryPage is one page of the history of an employee, newest
+// change first.
+type EmployeeHistoryPage struct {
+	PageInfo
+	Entries []*EmployeeAudit
+}
@@ -0,0 +1,71 @@
+package dto
+
+import "encoding/json"
+
+// Operations of a batch.
+const (
+	BatchCreate = "create"
+	BatchUpdate = "update"
+	BatchDelete = "delete"
+)
+
+// Statuses of a batch operation.
+const (
+	BatchOperationSucceeded = "succeeded"
+	BatchOperationFailed    = "failed"
+	// BatchOperationRolledBack marks an operation that succeeded but was
+	// undone because another operation of an atomic batch failed.
+	BatchOperationRolledBack = "rolled_back"
+	// BatchOperationSkipped marks an operation that was not run because an
+	// earlier operation of an atomic batch failed.
+	BatchOperationSkipped = "skipped"
+)
+
+// BatchRequest is the body of the batch endpoint.
+type BatchRequest struct {
+	// Atomic stops at the first failed operation and undoes the ones
+	// before it. It defaults to true; a batch that is not atomic runs
+	// every operation and keeps the ones that succeed.
+	Atomic     *bool             `json:"atomic"`
+	Operations []*BatchOperation `json:"operations" binding:"required,min=1,max=100,dive,required"`
+}
+
+// BatchOperation is one create, update or delete of a batch.
+type BatchOperation struct {
+	Op string `json:"op" binding:"required,oneof=create update delete"`
+	// ID is the employee an update or delete applies to.
+	ID int `json:"id" binding:"required_unless=Op create,excluded_if=Op create,gte=0"`
+	// IfMatch is the If-Match header of an update or delete, e.g. the
+	// ETag of the employee read before.
+	IfMatch string `json:"if_match" binding:"excluded_if=Op create"`
+	// Precondition is IfMatch as parsed by the transport.
+	Precondition Precondition `json:"-"`
+	// Body is the request body of a create or update, the same as for
+	// api/add-employee and api/employee/:employee_id.
+	Body json.RawMessage `json:"body" binding:"required_unless=Op delete,excluded_if=Op delete"`
+}
+
+// BatchResult describes the outcome of a batch operation by operation.
+type BatchResult struct {
+	Atomic bool `json:"atomic"`
+	// Committed reports whether the successful operations were stored.
+	Committed  bool                    `json:"committed"`
+	Total      int                     `json:"total"`
+	Succeeded  int                     `json:"succeeded"`
+	Failed     int                     `json:"failed"`
+	Operations []*BatchOperationResult `json:"operations"`
+}
+
+// BatchOperationResult is the outcome of the operation at Index of the
+// request.
+type BatchOperationResult struct {
+	Index  int    `json:"index"`
+	Op     string `json:"op"`
+	Status string `json:"status"`
+	// ID is the employee the operation applied to or created.
+	ID *int `json:"id,omitempty"`
+	// Employee is the employee as updated.
+	Employee *Employee `json:"employee,omitempty"`
+	// Err is the reason a failed operation failed.
+	Err error `json:"-"`
+}
@@ -0,0 +1,78 @@
+package dto
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// DateLayout is the format of calendar dates in requests.
+const DateLayout = "2006-01-02"
+
+// Compensation reasons.
+const (
+	ReasonHire             = "hire"
+	ReasonPromotion        = "promotion"
+	ReasonMerit            = "merit"
+	ReasonMarketAdjustment = "market_adjustment"
+	ReasonCostOfLiving     = "cost_of_living"
+	ReasonAdjustment       = "adjustment"
+	ReasonCorrection       = "correction"
+)
+
+// Compensation is the salary of an employee over a period of time. The
+// period starts at EffectiveFrom and ends before EffectiveTo; a nil
+// EffectiveTo means the record is the latest one.
+type Compensation struct {
+	ID            int64      `json:"id"`
+	EmployeeID    int        `json:"employee_id"`
+	Amount        float64    `json:"amount"`
+	Currency      string     `json:"currency"`
+	Reason        string     `json:"reason"`
+	EffectiveFrom time.Time  `json:"effective_from"`
+	EffectiveTo   *time.Time `json:"effective_to"`
+	// Active is set on the record in effect today.
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ActiveOn reports whether the record is in effect on the given day.
+func (c *Compensation) ActiveOn(day time.Time) bool {
+	return !c.EffectiveFrom.After(day) && (c.EffectiveTo == nil || c.EffectiveTo.After(day))
+}
+
+// MarshalJSON writes the effective dates as calendar dates.
+func (c *Compensation) MarshalJSON() ([]byte, error) {
+	type compensation Compensation
+	var effectiveTo *string
+	if c.EffectiveTo != nil {
+		day := c.EffectiveTo.Format(DateLayout)
+		effectiveTo = &day
+	}
+	return json.Marshal(struct {
+		*compensation
+		EffectiveFrom string  `json:"effective_from"`
+		EffectiveTo   *string `json:"effective_to"`
+	}{
+		compensation:  (*compensation)(c),
+		EffectiveFrom: c.EffectiveFrom.Format(DateLayout),
+		EffectiveTo:   effectiveTo,
+	})
+}
+
+// ScheduleCompensationRequest schedules a salary change from a day on.
+type ScheduleCompensationRequest struct {
+	Amount float64 `json:"amount" binding:"required,gt=0"`
+	// Currency defaults to the currency of the current record.
+	Currency      string `json:"currency" binding:"omitempty,iso4217"`
+	Reason        string `json:"reason" binding:"required,oneof=promotion merit market_adjustment cost_of_living adjustment correction"`
+	EffectiveFrom string `json:"effective_from" binding:"required,datetime=2006-01-02"`
+}
+
+// Normalize trims surrounding whitespace and upper cases the currency
+// before validation.
+func (r *ScheduleCompensationRequest) Normalize() {
+	r.Currency = strings.ToUpper(strings.TrimSpace(r.Currency))
+	r.Reason = strings.TrimSpace(r.Reason)
+	r.EffectiveFrom = strings.TrimSpace(r.EffectiveFrom)
+}
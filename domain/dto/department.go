@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"strings"
+	"time"
+)
+
+// Department groups employees.
+type Department struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// DepartmentRequest is the body of the create and update department
+// endpoints.
+type DepartmentRequest struct {
+	Name string `json:"name" binding:"required,max=255,nocontrol"`
+}
+
+// Normalize trims surrounding whitespace before validation.
+func (r *DepartmentRequest) Normalize() {
+	r.Name = strings.TrimSpace(r.Name)
+}
+
+type GetDepartmentByIDRequest struct {
+	DepartmentID int `json:"department_id" uri:"department_id" binding:"required"`
+}
+
+// GetDepartments holds the query parameters of the list departments
+// endpoint.
+type GetDepartments struct {
+	Page     int `json:"page" form:"page" binding:"gte=0"`
+	PageSize int `json:"page_size" form:"page_size" binding:"gte=0"`
+}
+
+// DepartmentPage is one page of departments, ordered by name.
+type DepartmentPage struct {
+	PageInfo
+	Departments []*Department
+}
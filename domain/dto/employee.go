@@ -1,18 +1,150 @@
 package dto
 
-import "time"
+import (
+	"strconv"
+	"strings"
+	"time"
+)
 
 type CreateEmployeeResponse struct {
 	Id int `json:"id"`
+	// Replayed is set when the response is the stored response of an
+	// earlier request with the same idempotency key.
+	Replayed bool `json:"-"`
 }
 
 type DeleteEmployeeRequest struct {
 	EmployeeID int `json:"employee_id" uri:"employee_id" binding:"required"`
 }
 
+// GetEmployee holds the query parameters of the list employees endpoint.
 type GetEmployee struct {
-	Page     int `json:"page" form:"page"`
-	PageSize int `json:"page_size" form:"page_size"`
+	// Page starts at 1; zero selects the first page.
+	Page int `json:"page" form:"page" binding:"gte=0"`
+	// PageSize zero selects the default page size.
+	PageSize int `json:"page_size" form:"page_size" binding:"gte=0"`
+
+	// Position matches any of the given positions exactly. It may be
+	// repeated or comma separated.
+	Position  []string `json:"position" form:"position" binding:"dive,max=255"`
+	SalaryMin *float64 `json:"salary_min" form:"salary_min" binding:"omitempty,gte=0"`
+	SalaryMax *float64 `json:"salary_max" form:"salary_max" binding:"omitempty,gte=0"`
+	// Name matches employees whose name contains it, ignoring case.
+	Name        string     `json:"name" form:"name" binding:"max=255"`
+	CreatedFrom *time.Time `json:"created_from" form:"created_from"`
+	CreatedTo   *time.Time `json:"created_to" form:"created_to"`
+	UpdatedFrom *time.Time `json:"updated_from" form:"updated_from"`
+	UpdatedTo   *time.Time `json:"updated_to" form:"updated_to"`
+	// DepartmentID matches employees of any of the given departments. It
+	// may be repeated.
+	DepartmentID []int `json:"department_id" form:"department_id" binding:"dive,gt=0"`
+	// Sort is a comma separated list of fields, each optionally prefixed
+	// with "-" for descending order, e.g. "salary,-created_at".
+	Sort string `json:"sort" form:"sort"`
+	// IncludeDeleted also lists soft deleted employees; OnlyDeleted lists
+	// nothing else.
+	IncludeDeleted bool `json:"include_deleted" form:"include_deleted"`
+	OnlyDeleted    bool `json:"only_deleted" form:"only_deleted"`
+
+	// Cursor continues a keyset listing from the next_cursor of the
+	// previous page. Keyset listings are selected by Cursor or Limit and
+	// cannot be combined with Page.
+	Cursor string `json:"cursor" form:"cursor"`
+	// Limit is the page size of a keyset listing.
+	Limit int `json:"limit" form:"limit" binding:"gte=0"`
+}
+
+// Normalize splits comma separated positions and trims every value.
+func (r *GetEmployee) Normalize() {
+	positions := make([]string, 0, len(r.Position))
+	for _, value := range r.Position {
+		for _, position := range strings.Split(value, ",") {
+			if position = strings.TrimSpace(position); position != "" {
+				positions = append(positions, position)
+			}
+		}
+	}
+	r.Position = positions
+	r.Name = strings.TrimSpace(r.Name)
+	r.Sort = strings.TrimSpace(r.Sort)
+	r.Cursor = strings.TrimSpace(r.Cursor)
+}
+
+// Keyset reports whether the request asks for a keyset listing.
+func (r *GetEmployee) Keyset() bool {
+	return r.Cursor != "" || r.Limit != 0
+}
+
+// EmployeeSortFields maps the sortable employee fields to their columns.
+var EmployeeSortFields = map[string]string{
+	"id":         "id",
+	"name":       "name",
+	"position":   "position",
+	"salary":     "salary",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// SortField orders a listing by a single field.
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// DeletedFilter selects employees by their soft delete state.
+type DeletedFilter int
+
+const (
+	// DeletedExclude lists employees that are not deleted.
+	DeletedExclude DeletedFilter = iota
+	// DeletedInclude lists deleted and not deleted employees.
+	DeletedInclude
+	// DeletedOnly lists deleted employees.
+	DeletedOnly
+)
+
+// EmployeeFilter restricts a listing of employees. Zero values do not filter.
+type EmployeeFilter struct {
+	Positions    []string
+	SalaryMin    *float64
+	SalaryMax    *float64
+	NameContains string
+	CreatedFrom  *time.Time
+	CreatedTo    *time.Time
+	UpdatedFrom  *time.Time
+	UpdatedTo    *time.Time
+	Deleted      DeletedFilter
+	// DepartmentIDs matches employees of any of the given departments.
+	DepartmentIDs []int
+}
+
+// PageInfo locates a page within a listing. Offset listings fill Page,
+// PageSize, TotalCount and TotalPages; keyset listings fill Limit and,
+// unless this is the last page, NextCursor.
+type PageInfo struct {
+	Page       int
+	PageSize   int
+	TotalCount int64
+	TotalPages int
+	Limit      int
+	NextCursor string
+}
+
+// EmployeePage is one page of a listing of employees.
+type EmployeePage struct {
+	PageInfo
+	Employees []*Employee
+}
+
+// EmployeeListQuery selects a page of employees.
+type EmployeeListQuery struct {
+	Filter EmployeeFilter
+	Sort   []SortField
+	// After selects the rows that sort strictly after the given employee.
+	// Only its sort fields and ID need to be set.
+	After  *Employee
+	Limit  int
+	Offset int
 }
 
 type GetEmployeeByIDRequest struct {
@@ -20,25 +152,70 @@ type GetEmployeeByIDRequest struct {
 }
 
 type EmployeeCreateRequest struct {
-	Name     string  `json:"name"`
-	Position string  `json:"position"`
-	Salary   float64 `json:"salary"`
+	Name         string  `json:"name" binding:"required,max=255,nocontrol"`
+	Position     string  `json:"position" binding:"required,max=255,nocontrol"`
+	Salary       float64 `json:"salary" binding:"required,gt=0"`
+	DepartmentID *int    `json:"department_id" binding:"omitempty,gt=0"`
+	ManagerID    *int    `json:"manager_id" binding:"omitempty,gt=0"`
+	// IdempotencyKey is the Idempotency-Key header of the request, if any.
+	IdempotencyKey string `json:"-"`
+}
+
+// Normalize trims surrounding whitespace before validation.
+func (r *EmployeeCreateRequest) Normalize() {
+	r.Name = strings.TrimSpace(r.Name)
+	r.Position = strings.TrimSpace(r.Position)
 }
 
 // Employee Represents the fields from the Employee Database
 type Employee struct {
-	ID        int       `json:"id"`
-	Name      string    `json:"name"`
-	Position  string    `json:"position"`
-	Salary    float64   `json:"salary"`
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Position string `json:"position"`
+	// Salary is left out of responses to callers who may not read it;
+	// stored salaries are always greater than 0.
+	Salary    float64   `json:"salary,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	// DeletedAt is set while the employee is soft deleted.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// DepartmentID is nil for employees without a department. Department
+	// is loaded along with it on reads.
+	DepartmentID *int        `json:"department_id"`
+	Department   *Department `json:"department,omitempty"`
+	// ManagerID is nil for employees at the top of the organization.
+	ManagerID *int `json:"manager_id"`
+}
+
+// Version identifies the stored state of the employee. It changes with
+// every update, which stamps updated_at.
+func (e *Employee) Version() string {
+	return strconv.FormatInt(e.UpdatedAt.UnixMicro(), 36)
 }
 
+// Precondition makes a write conditional on the version of the employee.
+type Precondition struct {
+	// IfMatch lists the versions the write may apply to; "*" matches any.
+	// An empty list makes the write unconditional.
+	IfMatch []string
+}
+
+// UpdateEmployeeBodyRequest replaces every mutable field of an employee;
+// partial updates go through EmployeePatch.
 type UpdateEmployeeBodyRequest struct {
-	Name     string  `json:"name"`
-	Position string  `json:"position"`
-	Salary   float64 `json:"salary"`
+	Name     string  `json:"name" binding:"required,max=255,nocontrol"`
+	Position string  `json:"position" binding:"required,max=255,nocontrol"`
+	Salary   float64 `json:"salary" binding:"required,gt=0"`
+	// DepartmentID nil removes the employee from its department.
+	DepartmentID *int `json:"department_id" binding:"omitempty,gt=0"`
+	// ManagerID nil makes the employee report to nobody.
+	ManagerID *int `json:"manager_id" binding:"omitempty,gt=0"`
+}
+
+// Normalize trims surrounding whitespace before validation.
+func (r *UpdateEmployeeBodyRequest) Normalize() {
+	r.Name = strings.TrimSpace(r.Name)
+	r.Position = strings.TrimSpace(r.Position)
 }
 
 type UpdateEmployeeRequest struct {
@@ -0,0 +1,9 @@
+package dto
+
+// OrgChartNode is an employee together with everyone reporting to it,
+// directly or indirectly.
+type OrgChartNode struct {
+	*Employee
+	// Reports are the direct reports of the employee, ordered by name.
+	Reports []*OrgChartNode `json:"reports"`
+}
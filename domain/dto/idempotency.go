@@ -0,0 +1,25 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// IdempotencyKey is a key chosen by a client to make a request safe to
+// retry: the server carries out the first request made with the key and
+// answers the retries with the response it stored. Keys are scoped to the
+// caller that made the request.
+type IdempotencyKey struct {
+	// Scope is the subject of the caller; empty for anonymous callers.
+	Scope string
+	Key   string
+	// RequestHash is the SHA-256 hash of the request, in hex. A key can
+	// only be retried with the same request.
+	RequestHash string
+	// Response is the JSON encoded response to the request; nil until the
+	// request succeeded.
+	Response  json.RawMessage
+	CreatedAt time.Time
+	// ExpiresAt is when the key may be used for another request.
+	ExpiresAt time.Time
+}
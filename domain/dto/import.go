@@ -0,0 +1,62 @@
+package dto
+
+// Modes of an employee import.
+const (
+	// ImportAllOrNothing creates no employee unless every row is valid.
+	ImportAllOrNothing = "all_or_nothing"
+	// ImportBestEffort creates the valid rows and reports the others.
+	ImportBestEffort = "best_effort"
+)
+
+// Statuses of an imported row.
+const (
+	ImportRowCreated = "created"
+	// ImportRowValid marks a valid row of a dry run.
+	ImportRowValid = "valid"
+	// ImportRowSkipped marks a valid row that was not created because
+	// another row of an all_or_nothing import failed.
+	ImportRowSkipped = "skipped"
+	ImportRowFailed  = "failed"
+)
+
+// ImportEmployeesRequest holds the form fields of the import endpoint that
+// accompany the CSV file.
+type ImportEmployeesRequest struct {
+	// Mode defaults to ImportAllOrNothing.
+	Mode string `json:"mode" form:"mode" binding:"omitempty,oneof=all_or_nothing best_effort"`
+	// DryRun validates every row without creating any employee.
+	DryRun bool `json:"dry_run" form:"dry_run"`
+	// Mapping is a JSON object mapping employee fields to CSV headers, e.g.
+	// {"name":"Full Name"}. Unmapped fields are read from the column whose
+	// header is the field name, ignoring case.
+	Mapping string `json:"mapping" form:"mapping"`
+}
+
+// ImportReport describes the outcome of an import row by row.
+type ImportReport struct {
+	Mode   string `json:"mode"`
+	DryRun bool   `json:"dry_run"`
+	// Committed reports whether the created employees were stored.
+	Committed bool               `json:"committed"`
+	Total     int                `json:"total"`
+	Created   int                `json:"created"`
+	Failed    int                `json:"failed"`
+	Rows      []*ImportRowResult `json:"rows"`
+}
+
+// ImportRowResult is the outcome of one data row. Row is the line of the
+// record in the file, the header being row 1.
+type ImportRowResult struct {
+	Row    int               `json:"row"`
+	Status string            `json:"status"`
+	ID     *int              `json:"id,omitempty"`
+	Errors []*ImportRowError `json:"errors,omitempty"`
+}
+
+// ImportRowError is a problem with a row, usually with one of its fields.
+type ImportRowError struct {
+	Field   string `json:"field,omitempty"`
+	Column  string `json:"column,omitempty"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,19 @@
+package dto
+
+// PatchFormat is the media type of a PATCH request body.
+type PatchFormat int
+
+const (
+	// PatchMerge is an RFC 7396 JSON Merge Patch (application/merge-patch+json).
+	PatchMerge PatchFormat = iota
+	// PatchJSON is an RFC 6902 JSON Patch (application/json-patch+json).
+	PatchJSON
+)
+
+// EmployeePatch is a partial update of an employee. Document is applied to
+// the JSON form of UpdateEmployeeBodyRequest, so a patch can only touch
+// name, position and salary.
+type EmployeePatch struct {
+	Format   PatchFormat
+	Document []byte
+}
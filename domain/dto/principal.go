@@ -0,0 +1,41 @@
+package dto
+
+import "slices"
+
+// Permissions granted to roles by the authorization policy.
+const (
+	PermissionEmployeeRead    = "employee:read"
+	PermissionEmployeeWrite   = "employee:write"
+	PermissionEmployeePurge   = "employee:purge"
+	PermissionSalaryRead      = "salary:read"
+	PermissionSalaryWrite     = "salary:write"
+	PermissionDepartmentRead  = "department:read"
+	PermissionDepartmentWrite = "department:write"
+	PermissionAPIKeyManage    = "api_key:manage"
+)
+
+// Permissions lists every permission a role may be granted.
+var Permissions = []string{
+	PermissionEmployeeRead,
+	PermissionEmployeeWrite,
+	PermissionEmployeePurge,
+	PermissionSalaryRead,
+	PermissionSalaryWrite,
+	PermissionDepartmentRead,
+	PermissionDepartmentWrite,
+	PermissionAPIKeyManage,
+}
+
+// Principal identifies the caller on whose behalf a usecase runs.
+type Principal struct {
+	Subject string `json:"subject"`
+	// Roles are the roles the caller holds; Permissions are the ones
+	// these roles grant.
+	Roles       []string `json:"roles,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+}
+
+// Can reports whether the principal has been granted permission.
+func (p *Principal) Can(permission string) bool {
+	return slices.Contains(p.Permissions, permission)
+}
@@ -0,0 +1,64 @@
+package dto
+
+import (
+	"math"
+	"time"
+)
+
+// RateLimit is a token bucket holding up to Burst tokens and refilled with
+// Requests tokens per Period. Every request takes one token.
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+	// Burst is the capacity of the bucket; zero means Requests.
+	Burst int
+}
+
+// Capacity is the largest number of tokens the bucket holds.
+func (l RateLimit) Capacity() float64 {
+	if l.Burst > 0 {
+		return float64(l.Burst)
+	}
+	return float64(l.Requests)
+}
+
+// Rate is the number of tokens added per second.
+func (l RateLimit) Rate() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+// Result describes a bucket holding tokens after a request was allowed or
+// denied.
+func (l RateLimit) Result(tokens float64, allowed bool) RateLimitResult {
+	result := RateLimitResult{
+		Allowed:   allowed,
+		Limit:     int(l.Capacity()),
+		Remaining: int(math.Max(0, math.Floor(tokens))),
+		Reset:     l.wait(l.Capacity() - tokens),
+	}
+	if !allowed {
+		result.RetryAfter = l.wait(1 - tokens)
+	}
+	return result
+}
+
+// wait is the time it takes to refill the given number of tokens.
+func (l RateLimit) wait(tokens float64) time.Duration {
+	if tokens <= 0 {
+		return 0
+	}
+	return time.Duration(math.Ceil(tokens / l.Rate() * float64(time.Second)))
+}
+
+// RateLimitResult is the outcome of taking a token.
+type RateLimitResult struct {
+	Allowed bool
+	// Limit is the capacity of the bucket and Remaining the whole tokens
+	// left in it.
+	Limit     int
+	Remaining int
+	// Reset is the time until the bucket is full again; RetryAfter the
+	// time until a denied request would be allowed.
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
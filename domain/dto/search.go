@@ -0,0 +1,42 @@
+package dto
+
+import "strings"
+
+// SearchEmployees holds the query parameters of the search endpoint.
+type SearchEmployees struct {
+	// Q is the text searched for in the names and positions of employees.
+	Q string `json:"q" form:"q" binding:"required,max=255,nocontrol"`
+	// Limit is the largest number of results; zero selects the default.
+	Limit int `json:"limit" form:"limit" binding:"gte=0,lte=100"`
+}
+
+// Normalize trims surrounding whitespace before validation.
+func (r *SearchEmployees) Normalize() {
+	r.Q = strings.TrimSpace(r.Q)
+}
+
+// EmployeeSearchQuery selects the employees matching a search.
+type EmployeeSearchQuery struct {
+	// Text is matched by trigram similarity.
+	Text string
+	// Words are matched as prefixes of the words of the name and position.
+	Words []string
+	// MinSimilarity is the word similarity, between 0 and 1, from which
+	// Text matches.
+	MinSimilarity float64
+	Limit         int
+}
+
+// EmployeeSearchResult is an employee matching a search and how well it
+// matches.
+type EmployeeSearchResult struct {
+	*Employee
+	// Score orders the results; it is Rank plus Similarity.
+	Score float64 `json:"score"`
+	// Rank is the full text rank of the words, matches in the name
+	// weighing more than matches in the position.
+	Rank float64 `json:"rank"`
+	// Similarity is the trigram word similarity of the text with the name
+	// or position, whichever is higher.
+	Similarity float64 `json:"similarity"`
+}
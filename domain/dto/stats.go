@@ -0,0 +1,77 @@
+package dto
+
+import "strings"
+
+// EmployeeGroupFields maps the fields employees can be grouped by to their
+// columns.
+var EmployeeGroupFields = map[string]string{
+	"position":      "position",
+	"department_id": "department_id",
+	"manager_id":    "manager_id",
+}
+
+// GetEmployeeStats holds the query parameters of the stats endpoint that
+// come on top of the filters of the list endpoint.
+type GetEmployeeStats struct {
+	// GroupBy is a comma separated list of EmployeeGroupFields; without it
+	// the stats cover every employee matching the filters.
+	GroupBy string `json:"group_by" form:"group_by"`
+	// Percentiles are fractions between 0 and 1, e.g. 0.9 for the 90th
+	// percentile. They may be repeated or comma separated.
+	Percentiles []string `json:"percentiles" form:"percentiles" binding:"max=20"`
+}
+
+// Normalize splits comma separated percentiles and trims every value.
+func (r *GetEmployeeStats) Normalize() {
+	percentiles := make([]string, 0, len(r.Percentiles))
+	for _, value := range r.Percentiles {
+		for _, percentile := range strings.Split(value, ",") {
+			if percentile = strings.TrimSpace(percentile); percentile != "" {
+				percentiles = append(percentiles, percentile)
+			}
+		}
+	}
+	r.Percentiles = percentiles
+	r.GroupBy = strings.TrimSpace(r.GroupBy)
+}
+
+// EmployeeStatsQuery selects the employees to aggregate and how.
+type EmployeeStatsQuery struct {
+	Filter EmployeeFilter
+	// GroupBy lists fields of EmployeeGroupFields.
+	GroupBy     []string
+	Percentiles []float64
+}
+
+// EmployeeStats are the salary statistics of groups of employees.
+type EmployeeStats struct {
+	GroupBy []string              `json:"group_by"`
+	Groups  []*EmployeeStatsGroup `json:"groups"`
+}
+
+// EmployeeStatsGroup aggregates the employees sharing the values of Group.
+type EmployeeStatsGroup struct {
+	// Group maps every group_by field to its value in the group; it is
+	// empty when the stats are not grouped.
+	Group     map[string]interface{} `json:"group"`
+	Headcount int64                  `json:"headcount"`
+	Salary    SalaryStats            `json:"salary"`
+}
+
+// SalaryStats aggregate the current salaries of a group. They are nil for
+// a group without employees.
+type SalaryStats struct {
+	Sum         *float64           `json:"sum"`
+	Mean        *float64           `json:"mean"`
+	Median      *float64           `json:"median"`
+	Min         *float64           `json:"min"`
+	Max         *float64           `json:"max"`
+	Percentiles []SalaryPercentile `json:"percentiles"`
+}
+
+// SalaryPercentile is the salary below which the fraction Percentile of a
+// group earns, interpolated between salaries.
+type SalaryPercentile struct {
+	Percentile float64  `json:"percentile"`
+	Value      *float64 `json:"value"`
+}
@@ -0,0 +1,38 @@
+package interfaces
+
+import (
+	"context"
+	"employee-management/domain/dto"
+	"time"
+)
+
+// APIKeyUsecase manages the API keys of service clients and authenticates
+// the requests made with them.
+type APIKeyUsecase interface {
+	// CreateAPIKey issues a new key. The returned secret cannot be
+	// recovered later.
+	CreateAPIKey(ctx context.Context, request *dto.CreateAPIKeyRequest) (*dto.CreatedAPIKey, error)
+	// ListAPIKeys returns a page of keys, revoked and expired ones
+	// included.
+	ListAPIKeys(ctx context.Context, request *dto.GetAPIKeys) (*dto.APIKeyPage, error)
+	RevokeAPIKey(ctx context.Context, apiKeyID int64) error
+	// AuthenticateAPIKey returns the principal of a key, granted the scopes
+	// of the key, or a KindUnauthenticated error when the key is unknown,
+	// revoked or expired.
+	AuthenticateAPIKey(ctx context.Context, key string) (*dto.Principal, error)
+}
+
+// APIKeyRepository persists API keys. Like EmployeeRepository it reports
+// failures as apperror errors.
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *dto.APIKey) error
+	// List returns keys newest first.
+	List(ctx context.Context, limit, offset int) ([]*dto.APIKey, error)
+	Count(ctx context.Context) (int64, error)
+	FindByHash(ctx context.Context, hash string) (*dto.APIKey, error)
+	// Revoke stamps revoked_at on a key unless it is already revoked.
+	Revoke(ctx context.Context, apiKeyID int64) error
+	// Touch records that a key was used at the given time. It may skip the
+	// write when the key was used shortly before.
+	Touch(ctx context.Context, apiKeyID int64, at time.Time) error
+}
@@ -0,0 +1,28 @@
+package interfaces
+
+import (
+	"context"
+	"employee-management/domain/dto"
+)
+
+// DepartmentUsecase holds the department business rules.
+type DepartmentUsecase interface {
+	GetDepartmentByID(ctx context.Context, departmentID int) (*dto.Department, error)
+	GetAllDepartments(ctx context.Context, request *dto.GetDepartments) (*dto.DepartmentPage, error)
+	CreateDepartment(ctx context.Context, request *dto.DepartmentRequest) (*dto.Department, error)
+	UpdateDepartment(ctx context.Context, departmentID int, request *dto.DepartmentRequest) (*dto.Department, error)
+	DeleteDepartment(ctx context.Context, departmentID int) error
+}
+
+// DepartmentRepository persists departments. Like EmployeeRepository it
+// reports failures as apperror errors.
+type DepartmentRepository interface {
+	FindByID(ctx context.Context, departmentID int) (*dto.Department, error)
+	// List returns departments ordered by name.
+	List(ctx context.Context, limit, offset int) ([]*dto.Department, error)
+	Count(ctx context.Context) (int64, error)
+	Create(ctx context.Context, department *dto.Department) error
+	// Update stores the name of a department.
+	Update(ctx context.Context, department *dto.Department) error
+	Delete(ctx context.Context, departmentID int) error
+}
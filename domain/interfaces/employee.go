@@ -1,15 +1,129 @@
 package interfaces
 
 import (
+	"context"
 	"employee-management/domain/dto"
-
-	"github.com/gin-gonic/gin"
+	"io"
 )
 
+// EmployeeUsecase holds the employee business rules. It is transport
+// agnostic: request scoped values such as the request ID and the caller
+// identity travel in ctx (see utils/requestctx).
 type EmployeeUsecase interface {
-	GetEmployeeById(ctx *gin.Context, employeeID int) (*dto.Employee, error)
-	GetAllEmployee(ctx *gin.Context, limit int, offset int) ([]*dto.Employee, error)
-	CreateEmployee(ctx *gin.Context, request *dto.EmployeeCreateRequest) (dto.CreateEmployeeResponse, error)
-	UpdateEmployee(ctx *gin.Context, employeeID int, requestBody *dto.UpdateEmployeeBodyRequest) (*dto.Employee, error)
-	DeleteEmployee(ctx *gin.Context, employeeID int) error
+	GetEmployeeById(ctx context.Context, employeeID int) (*dto.Employee, error)
+	GetAllEmployee(ctx context.Context, request *dto.GetEmployee) (*dto.EmployeePage, error)
+	// SearchEmployees finds employees by words of their name or position,
+	// tolerating typos and partial words.
+	SearchEmployees(ctx context.Context, request *dto.SearchEmployees) ([]*dto.EmployeeSearchResult, error)
+	// GetEmployeeStats aggregates the salaries of the employees matching the
+	// filters of request, optionally per group.
+	GetEmployeeStats(ctx context.Context, request *dto.GetEmployee, stats *dto.GetEmployeeStats) (*dto.EmployeeStats, error)
+	// ExportEmployees calls fn with every employee matching the filters
+	// and sort order of request, without holding them all in memory.
+	ExportEmployees(ctx context.Context, request *dto.GetEmployee, fn func(*dto.Employee) error) error
+	CreateEmployee(ctx context.Context, request *dto.EmployeeCreateRequest) (dto.CreateEmployeeResponse, error)
+	// ImportEmployees creates employees from the rows of a CSV file and
+	// reports the outcome of every row.
+	ImportEmployees(ctx context.Context, file io.Reader, request *dto.ImportEmployeesRequest) (*dto.ImportReport, error)
+	// BatchEmployees runs creates, updates and deletes in one transaction
+	// and reports the outcome of every operation.
+	BatchEmployees(ctx context.Context, request *dto.BatchRequest) (*dto.BatchResult, error)
+	UpdateEmployee(ctx context.Context, employeeID int, requestBody *dto.UpdateEmployeeBodyRequest, precondition dto.Precondition) (*dto.Employee, error)
+	PatchEmployee(ctx context.Context, employeeID int, patch dto.EmployeePatch, precondition dto.Precondition) (*dto.Employee, error)
+	DeleteEmployee(ctx context.Context, employeeID int, precondition dto.Precondition) error
+	RestoreEmployee(ctx context.Context, employeeID int, precondition dto.Precondition) (*dto.Employee, error)
+	PurgeEmployee(ctx context.Context, employeeID int) error
+	GetEmployeeHistory(ctx context.Context, employeeID int, request *dto.GetEmployeeHistory) (*dto.EmployeeHistoryPage, error)
+	ScheduleCompensation(ctx context.Context, employeeID int, request *dto.ScheduleCompensationRequest) (*dto.Compensation, error)
+	GetCompensationTimeline(ctx context.Context, employeeID int) ([]*dto.Compensation, error)
+	// GetDepartmentEmployees lists the employees of a department like
+	// GetAllEmployee.
+	GetDepartmentEmployees(ctx context.Context, departmentID int, request *dto.GetEmployee) (*dto.EmployeePage, error)
+	// GetDirectReports lists the employees managed by an employee.
+	GetDirectReports(ctx context.Context, employeeID int) ([]*dto.Employee, error)
+	// GetReportingChain lists the managers of an employee from its direct
+	// manager up to the top of the organization.
+	GetReportingChain(ctx context.Context, employeeID int) ([]*dto.Employee, error)
+	// GetOrgChart returns the employee with everyone reporting to it.
+	GetOrgChart(ctx context.Context, employeeID int) (*dto.OrgChartNode, error)
+}
+
+// EmployeeRepository persists employees. Implementations report failures
+// as apperror errors, e.g. KindNotFound when the employee does not exist.
+// Soft deleted employees are invisible to every method except List, Count,
+// LockDeletedByID, Restore and Purge.
+type EmployeeRepository interface {
+	FindByID(ctx context.Context, employeeID int) (*dto.Employee, error)
+	// LockByID is FindByID that also locks the row until the surrounding
+	// transaction ends.
+	LockByID(ctx context.Context, employeeID int) (*dto.Employee, error)
+	// LockDeletedByID is LockByID for a soft deleted employee.
+	LockDeletedByID(ctx context.Context, employeeID int) (*dto.Employee, error)
+	// LockSharedByID is FindByID that keeps the row from being locked by
+	// LockByID, and so from being deleted, until the surrounding
+	// transaction ends. Department is not loaded.
+	LockSharedByID(ctx context.Context, employeeID int) (*dto.Employee, error)
+	List(ctx context.Context, query dto.EmployeeListQuery) ([]*dto.Employee, error)
+	// Stream calls fn with every employee List would return, reading them
+	// one at a time; Department is not loaded. An error from fn stops the
+	// stream and is returned.
+	Stream(ctx context.Context, query dto.EmployeeListQuery, fn func(*dto.Employee) error) error
+	Count(ctx context.Context, filter dto.EmployeeFilter) (int64, error)
+	// Search returns the employees that are not deleted and match query,
+	// best match first. Department is not loaded. It must run within a
+	// transaction.
+	Search(ctx context.Context, query dto.EmployeeSearchQuery) ([]*dto.EmployeeSearchResult, error)
+	// SalaryStats aggregates the current salaries of the employees matching
+	// query.Filter, with one group per combination of the GroupBy values.
+	// Without GroupBy it returns a single group, even if no employee
+	// matches.
+	SalaryStats(ctx context.Context, query dto.EmployeeStatsQuery) ([]*dto.EmployeeStatsGroup, error)
+	Create(ctx context.Context, employee *dto.Employee) error
+	Update(ctx context.Context, employee *dto.Employee) error
+	// Delete soft deletes an employee.
+	Delete(ctx context.Context, employeeID int) error
+	// Restore undoes Delete and returns the restored employee.
+	Restore(ctx context.Context, employeeID int) (*dto.Employee, error)
+	// Purge removes an employee permanently. Soft deleted employees
+	// reporting to it lose their manager.
+	Purge(ctx context.Context, employeeID int) error
+
+	// LockHierarchy serializes changes of reporting lines until the
+	// surrounding transaction ends, so that concurrent changes cannot
+	// combine into a cycle.
+	LockHierarchy(ctx context.Context) error
+	// DirectReports returns the employees managed by managerID, ordered
+	// by name.
+	DirectReports(ctx context.Context, managerID int) ([]*dto.Employee, error)
+	// Managers returns every employee above employeeID in the reporting
+	// line, in no particular order.
+	Managers(ctx context.Context, employeeID int) ([]*dto.Employee, error)
+	// Subtree returns employeeID and every employee reporting to it,
+	// directly or indirectly, ordered by name.
+	Subtree(ctx context.Context, employeeID int) ([]*dto.Employee, error)
+}
+
+// EmployeeAuditRepository reads the audit log of employees. The log is
+// written by the EmployeeRepository as part of every change; salary
+// changes scheduled ahead join it on the day they take effect.
+type EmployeeAuditRepository interface {
+	// List returns the changes of an employee, newest first.
+	List(ctx context.Context, employeeID int, limit, offset int) ([]*dto.EmployeeAudit, error)
+	Count(ctx context.Context, employeeID int) (int64, error)
+}
+
+// CompensationRepository persists the salary timeline of employees.
+type CompensationRepository interface {
+	// List returns the records of an employee ordered by EffectiveFrom.
+	List(ctx context.Context, employeeID int) ([]*dto.Compensation, error)
+	Create(ctx context.Context, compensation *dto.Compensation) error
+	// Update stores the amount, currency, reason and EffectiveTo of a record.
+	Update(ctx context.Context, compensation *dto.Compensation) error
+}
+
+// Transactor runs a unit of work atomically. Repositories called with the
+// context passed to fn take part in the same transaction. A nested unit of
+// work that fails is undone on its own, leaving the surrounding one intact.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
 }
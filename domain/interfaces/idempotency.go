@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+	"employee-management/domain/dto"
+)
+
+// IdempotencyKeyRepository persists idempotency keys until they expire.
+type IdempotencyKeyRepository interface {
+	// Claim stores key, unless its scope holds an unexpired key of the same
+	// name, which is returned instead. A key claimed within a transaction
+	// makes concurrent claims of the same key wait until the transaction
+	// ends, so that only one request is carried out.
+	Claim(ctx context.Context, key *dto.IdempotencyKey) (*dto.IdempotencyKey, error)
+	// SaveResponse stores the response of a claimed key.
+	SaveResponse(ctx context.Context, key *dto.IdempotencyKey) error
+}
@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+	"employee-management/domain/dto"
+)
+
+// RateLimitStore keeps the token buckets of rate limited clients. Taking a
+// token is atomic, so that concurrent requests of a client cannot exceed
+// its limit.
+type RateLimitStore interface {
+	// Take takes a token from the bucket identified by key, creating a
+	// full bucket for keys seen for the first time.
+	Take(ctx context.Context, key string, limit dto.RateLimit) (dto.RateLimitResult, error)
+}
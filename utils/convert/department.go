@@ -0,0 +1,33 @@
+package convert
+
+import (
+	"employee-management/api/repository/sqlboiler"
+	"employee-management/domain/dto"
+)
+
+func ToDepartmentDTO(department *sqlboiler.Department) *dto.Department {
+	return &dto.Department{
+		ID:        department.ID,
+		Name:      department.Name,
+		CreatedAt: department.CreatedAt,
+		UpdatedAt: department.UpdatedAt,
+	}
+}
+
+func ToDepartmentSliceDTO(departmentSlice sqlboiler.DepartmentSlice) []*dto.Department {
+	departments := make([]*dto.Department, 0, len(departmentSlice))
+	for _, department := range departmentSlice {
+		departments = append(departments, ToDepartmentDTO(department))
+	}
+
+	return departments
+}
+
+func ToDepartmentModel(department *dto.Department) *sqlboiler.Department {
+	return &sqlboiler.Department{
+		ID:        department.ID,
+		Name:      department.Name,
+		CreatedAt: department.CreatedAt,
+		UpdatedAt: department.UpdatedAt,
+	}
+}
@@ -3,6 +3,8 @@ package convert
 import (
 	"employee-management/api/repository/sqlboiler"
 	"employee-management/domain/dto"
+
+	"github.com/volatiletech/null/v8"
 )
 
 func ToEmployeeDTO(employee *sqlboiler.Employee) *dto.Employee {
@@ -13,6 +15,12 @@ func ToEmployeeDTO(employee *sqlboiler.Employee) *dto.Employee {
 		Salary:    employee.Salary,
 		CreatedAt: employee.CreatedAt,
 		UpdatedAt: employee.UpdatedAt,
+		DeletedAt: employee.DeletedAt.Ptr(),
+	}
+	e.DepartmentID = employee.DepartmentID.Ptr()
+	e.ManagerID = employee.ManagerID.Ptr()
+	if department := employee.R.GetDepartment(); department != nil {
+		e.Department = ToDepartmentDTO(department)
 	}
 
 	return e
@@ -26,3 +34,17 @@ func ToEmployeeSliceDTO(employeeSlice sqlboiler.EmployeeSlice) []*dto.Employee {
 
 	return allemployee
 }
+
+func ToEmployeeModel(employee *dto.Employee) *sqlboiler.Employee {
+	return &sqlboiler.Employee{
+		ID:           employee.ID,
+		Name:         employee.Name,
+		Position:     employee.Position,
+		Salary:       employee.Salary,
+		CreatedAt:    employee.CreatedAt,
+		UpdatedAt:    employee.UpdatedAt,
+		DeletedAt:    null.TimeFromPtr(employee.DeletedAt),
+		DepartmentID: null.IntFromPtr(employee.DepartmentID),
+		ManagerID:    null.IntFromPtr(employee.ManagerID),
+	}
+}
@@ -0,0 +1,68 @@
+package httputil
+
+import (
+	"employee-management/domain/apperror"
+	"net/http"
+)
+
+// statusByKind is the single place where domain error kinds become HTTP statuses.
+var statusByKind = map[apperror.Kind]int{
+	apperror.KindInvalidInput:         http.StatusBadRequest,
+	apperror.KindNotFound:             http.StatusNotFound,
+	apperror.KindConflict:             http.StatusConflict,
+	apperror.KindValidation:           http.StatusUnprocessableEntity,
+	apperror.KindPreconditionFailed:   http.StatusPreconditionFailed,
+	apperror.KindForbidden:            http.StatusForbidden,
+	apperror.KindUnsupportedMediaType: http.StatusUnsupportedMediaType,
+	apperror.KindPreconditionRequired: http.StatusPreconditionRequired,
+	apperror.KindNotAcceptable:        http.StatusNotAcceptable,
+	apperror.KindUnauthenticated:      http.StatusUnauthorized,
+}
+
+// StandardErrors maps err onto an HTTP status and the errors to report.
+// Errors that are not apperror errors become a 500 whose detail does not
+// reveal the cause.
+func StandardErrors(err error) (int, []StandardError) {
+	appErr, ok := apperror.As(err)
+	if !ok {
+		return http.StatusInternalServerError, []StandardError{{
+			Code:   "internal_error",
+			Title:  http.StatusText(http.StatusInternalServerError),
+			Detail: "an unexpected error occurred",
+		}}
+	}
+
+	status, ok := statusByKind[appErr.Kind]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+
+	if len(appErr.Fields) == 0 {
+		return status, []StandardError{{
+			Code:   appErr.Code,
+			Title:  http.StatusText(status),
+			Detail: appErr.Message,
+		}}
+	}
+
+	errs := make([]StandardError, 0, len(appErr.Fields))
+	for _, field := range appErr.Fields {
+		errs = append(errs, StandardError{
+			Code:   field.Code,
+			Title:  http.StatusText(status),
+			Detail: field.Message,
+			Object: ErrorObject{
+				Pointer: field.Pointer,
+			},
+		})
+	}
+	return status, errs
+}
+
+// WriteError writes err in the StandardEnvelope error format and returns
+// the status written.
+func WriteError(w http.ResponseWriter, err error) int {
+	status, errs := StandardErrors(err)
+	WriteErrorResponse(w, status, errs)
+	return status
+}
@@ -0,0 +1,56 @@
+package httputil
+
+import (
+	"database/sql"
+	"employee-management/domain/apperror"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToStandardErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+	}{
+		{"not found", apperror.Wrap(sql.ErrNoRows, apperror.KindNotFound, "employee_not_found", "employee not found"), http.StatusNotFound, "employee_not_found"},
+		{"conflict", apperror.Conflict("employee_already_exists", "employee already exists"), http.StatusConflict, "employee_already_exists"},
+		{"validation", apperror.Validation("validation_failed", "invalid employee"), http.StatusUnprocessableEntity, "validation_failed"},
+		{"precondition", apperror.PreconditionFailed("etag_mismatch", "stale"), http.StatusPreconditionFailed, "etag_mismatch"},
+		{"forbidden", apperror.Forbidden("forbidden", "no"), http.StatusForbidden, "forbidden"},
+		{"not acceptable", apperror.New(apperror.KindNotAcceptable, "not_acceptable", "no"), http.StatusNotAcceptable, "not_acceptable"},
+		{"unauthenticated", apperror.New(apperror.KindUnauthenticated, "invalid_api_key", "no"), http.StatusUnauthorized, "invalid_api_key"},
+		{"bad request", apperror.Wrap(errors.New("bad json"), apperror.KindInvalidInput, "invalid_request", "bad json"), http.StatusBadRequest, "invalid_request"},
+		{"unexpected", errors.New("connection refused"), http.StatusInternalServerError, "internal_error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, errs := StandardErrors(tt.err)
+
+			assert.Equal(t, tt.status, status)
+			assert.Len(t, errs, 1)
+			assert.Equal(t, tt.code, errs[0].Code)
+			assert.Equal(t, http.StatusText(tt.status), errs[0].Title)
+		})
+	}
+}
+
+func TestToStandardErrorsReportsFields(t *testing.T) {
+	err := apperror.ValidationFields("validation_failed", "request validation failed", []apperror.FieldError{
+		{Pointer: "/name", Code: "field_required", Message: "name is required"},
+		{Pointer: "/salary", Code: "field_out_of_range", Message: "salary must be greater than 0"},
+	})
+
+	status, errs := StandardErrors(err)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "field_required", errs[0].Code)
+	assert.Equal(t, "/name", errs[0].Object.Pointer)
+	assert.Equal(t, "/salary", errs[1].Object.Pointer)
+}
@@ -0,0 +1,31 @@
+package httputil
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ETag formats version as a strong entity tag.
+func ETag(version string) string {
+	return strconv.Quote(version)
+}
+
+// ParseIfMatch returns the entity tags of an If-Match header without their
+// quotes. "*" is returned as is. Weak tags keep their W/ prefix, so they
+// never equal a version: If-Match uses the strong comparison.
+func ParseIfMatch(header string) []string {
+	var tags []string
+	for _, part := range strings.Split(header, ",") {
+		part = strings.TrimSpace(part)
+		switch {
+		case part == "":
+		case part == "*":
+			tags = append(tags, part)
+		case strings.HasPrefix(part, "W/"):
+			tags = append(tags, "W/"+strings.Trim(part[2:], `"`))
+		default:
+			tags = append(tags, strings.Trim(part, `"`))
+		}
+	}
+	return tags
+}
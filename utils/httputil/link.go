@@ -0,0 +1,59 @@
+package httputil
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Link is a single RFC 8288 web link.
+type Link struct {
+	URL string
+	Rel string
+}
+
+// LinkHeader formats links as the value of an RFC 8288 Link header.
+func LinkHeader(links []Link) string {
+	parts := make([]string, 0, len(links))
+	for _, link := range links {
+		parts = append(parts, "<"+link.URL+`>; rel="`+link.Rel+`"`)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// RequestURL returns the absolute URL of r with the query parameters in
+// set replaced. Parameters set to an empty string are removed. The scheme
+// follows X-Forwarded-Proto, which middleware.ForwardedProto only lets
+// through from trusted proxies.
+func RequestURL(r *http.Request, set map[string]string) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto == "http" || proto == "https" {
+		scheme = proto
+	}
+
+	query := r.URL.Query()
+	for key, value := range set {
+		if value == "" {
+			query.Del(key)
+			continue
+		}
+		query.Set(key, value)
+	}
+
+	u := url.URL{
+		Scheme:   scheme,
+		Host:     r.Host,
+		Path:     r.URL.Path,
+		RawQuery: query.Encode(),
+	}
+	return u.String()
+}
+
+// PageURL returns the absolute URL of r pointing at the given page.
+func PageURL(r *http.Request, page int) string {
+	return RequestURL(r, map[string]string{"page": strconv.Itoa(page)})
+}
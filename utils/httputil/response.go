@@ -33,4 +33,6 @@ type StandardError struct {
 type ErrorObject struct {
 	Text []string `json:"text"`
 	Type int64    `json:"type"`
+	// Pointer is the JSON pointer (RFC 6901) of the request field the error is about.
+	Pointer string `json:"pointer,omitempty"`
 }
@@ -51,6 +51,7 @@ func WriteErrorResponse(w http.ResponseWriter, code int, errs []StandardError) {
 	errResponse, err := json.Marshal(response)
 	if err != nil {
 		WriteResponse(w, []byte(fmt.Sprintf(`{"errors":[{"code":"500","title":"Internal Server Error","detail":"%s","object":{"text":null,"type":0}}]}`, err.Error())), http.StatusInternalServerError, contentType)
+		return
 	}
 
 	WriteResponse(w, errResponse, code, contentType)
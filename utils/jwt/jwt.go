@@ -0,0 +1,244 @@
+// Package jwt verifies JSON Web Tokens signed with HS256, RS256 or ES256.
+// It only verifies tokens; the server never issues them.
+package jwt
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Signing algorithms accepted by a Verifier.
+const (
+	HS256 = "HS256"
+	RS256 = "RS256"
+	ES256 = "ES256"
+)
+
+// Errors returned by Verify. They describe why a token is rejected and may
+// be shown to the client.
+var (
+	ErrMalformed     = errors.New("token is malformed")
+	ErrAlgorithm     = errors.New("token signing algorithm is not accepted")
+	ErrUnknownKey    = errors.New("token is signed with an unknown key")
+	ErrSignature     = errors.New("token signature is invalid")
+	ErrExpired       = errors.New("token is expired")
+	ErrNotValidYet   = errors.New("token is not valid yet")
+	ErrIssuer        = errors.New("token issuer is not accepted")
+	ErrAudience      = errors.New("token audience is not accepted")
+	ErrMissingClaims = errors.New("token must have the sub and exp claims")
+)
+
+// Claims are the registered claims of a verified token. Raw holds every
+// claim, registered or not.
+type Claims struct {
+	Subject   string       `json:"sub"`
+	Issuer    string       `json:"iss"`
+	Audience  Audience     `json:"aud"`
+	ExpiresAt *NumericDate `json:"exp"`
+	NotBefore *NumericDate `json:"nbf"`
+	IssuedAt  *NumericDate `json:"iat"`
+
+	Raw map[string]interface{} `json:"-"`
+}
+
+// Audience is the aud claim, which may be a string or an array of strings.
+type Audience []string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (a *Audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = Audience{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*a = list
+	return nil
+}
+
+// NumericDate is a time claim in seconds since the epoch.
+type NumericDate struct {
+	time.Time
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Fractions of a second are
+// kept.
+func (d *NumericDate) UnmarshalJSON(data []byte) error {
+	var seconds json.Number
+	if err := json.Unmarshal(data, &seconds); err != nil {
+		return err
+	}
+	value, err := seconds.Float64()
+	if err != nil {
+		return err
+	}
+	d.Time = time.Unix(0, int64(value*float64(time.Second)))
+	return nil
+}
+
+// Options are the claims a Verifier checks besides the signature.
+type Options struct {
+	// Issuer, when set, must equal the iss claim.
+	Issuer string
+	// Audience, when set, must be one of the aud claim.
+	Audience string
+	// Leeway tolerates clock skew when checking exp and nbf.
+	Leeway time.Duration
+}
+
+// Verifier checks the signature and claims of tokens.
+type Verifier struct {
+	keys []*Key
+	opts Options
+	now  func() time.Time
+}
+
+// NewVerifier returns a Verifier accepting tokens signed with any of keys.
+func NewVerifier(keys []*Key, opts Options) *Verifier {
+	return &Verifier{keys: keys, opts: opts, now: time.Now}
+}
+
+type header struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+// Verify parses token and returns its claims if its signature is valid
+// and its claims are acceptable. Tokens must carry sub and exp.
+func (v *Verifier) Verify(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformed
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, ErrMalformed
+	}
+	if !slices.Contains([]string{HS256, RS256, ES256}, h.Algorithm) {
+		return nil, ErrAlgorithm
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	if err := v.verifySignature(h, []byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return nil, err
+	}
+
+	claims := new(Claims)
+	if err := decodeSegment(parts[1], claims); err != nil {
+		return nil, ErrMalformed
+	}
+	if err := decodeSegment(parts[1], &claims.Raw); err != nil {
+		return nil, ErrMalformed
+	}
+	if err := v.checkClaims(claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// verifySignature tries every key that may have signed a token with h.
+func (v *Verifier) verifySignature(h header, signed, signature []byte) error {
+	digest := sha256.Sum256(signed)
+	candidates := 0
+	for _, key := range v.keys {
+		if (h.KeyID != "" && key.ID != "" && key.ID != h.KeyID) || !key.accepts(h.Algorithm) {
+			continue
+		}
+		candidates++
+		if key.verify(signed, digest[:], signature) {
+			return nil
+		}
+	}
+	if candidates == 0 {
+		return ErrUnknownKey
+	}
+	return ErrSignature
+}
+
+func (v *Verifier) checkClaims(claims *Claims) error {
+	if claims.Subject == "" || claims.ExpiresAt == nil {
+		return ErrMissingClaims
+	}
+	now := v.now()
+	if !now.Before(claims.ExpiresAt.Add(v.opts.Leeway)) {
+		return ErrExpired
+	}
+	if claims.NotBefore != nil && now.Add(v.opts.Leeway).Before(claims.NotBefore.Time) {
+		return ErrNotValidYet
+	}
+	if v.opts.Issuer != "" && claims.Issuer != v.opts.Issuer {
+		return ErrIssuer
+	}
+	if v.opts.Audience != "" && !slices.Contains(claims.Audience, v.opts.Audience) {
+		return ErrAudience
+	}
+	return nil
+}
+
+// accepts reports whether key may verify tokens signed with algorithm.
+func (k *Key) accepts(algorithm string) bool {
+	if k.Algorithm != "" && k.Algorithm != algorithm {
+		return false
+	}
+	switch key := k.key.(type) {
+	case []byte:
+		return algorithm == HS256
+	case *rsa.PublicKey:
+		return algorithm == RS256
+	case *ecdsa.PublicKey:
+		return algorithm == ES256 && key.Curve.Params().Name == "P-256"
+	}
+	return false
+}
+
+// verify checks the signature of signed, whose SHA-256 digest is digest.
+func (k *Key) verify(signed, digest, signature []byte) bool {
+	switch key := k.key.(type) {
+	case []byte:
+		mac := hmac.New(sha256.New, key)
+		mac.Write(signed)
+		return hmac.Equal(mac.Sum(nil), signature)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest, signature) == nil
+	case *ecdsa.PublicKey:
+		// JWS encodes ES256 signatures as r and s of 32 bytes each
+		if len(signature) != 64 {
+			return false
+		}
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		return ecdsa.Verify(key, digest, r, s)
+	}
+	return false
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return errors.Wrap(err, "failed to decode token segment")
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testNow    = time.Unix(1700000000, 0)
+	testSecret = []byte("0123456789abcdef0123456789abcdef")
+)
+
+// sign returns a token of claims signed by signer, which is an HMAC secret,
+// *rsa.PrivateKey or *ecdsa.PrivateKey.
+func sign(t *testing.T, h map[string]interface{}, claims map[string]interface{}, signer interface{}) string {
+	t.Helper()
+	segment := func(v interface{}) string {
+		data, err := json.Marshal(v)
+		require.NoError(t, err)
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	signed := segment(h) + "." + segment(claims)
+	digest := sha256.Sum256([]byte(signed))
+
+	var signature []byte
+	switch key := signer.(type) {
+	case []byte:
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(signed))
+		signature = mac.Sum(nil)
+	case *rsa.PrivateKey:
+		var err error
+		signature, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+		require.NoError(t, err)
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+		require.NoError(t, err)
+		signature = make([]byte, 64)
+		r.FillBytes(signature[:32])
+		s.FillBytes(signature[32:])
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func validClaims() map[string]interface{} {
+	return map[string]interface{}{
+		"sub": "alice",
+		"iss": "https://issuer.example",
+		"aud": []string{"employees", "other"},
+		"exp": testNow.Add(time.Hour).Unix(),
+		"nbf": testNow.Add(-time.Minute).Unix(),
+	}
+}
+
+func newTestVerifier(keys []*Key, opts Options) *Verifier {
+	v := NewVerifier(keys, opts)
+	v.now = func() time.Time { return testNow }
+	return v
+}
+
+func writeFile(t *testing.T, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func TestVerifyAlgorithms(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	v := newTestVerifier([]*Key{
+		{Algorithm: HS256, key: testSecret},
+		{ID: "rsa", key: &rsaKey.PublicKey},
+		{ID: "ec", key: &ecKey.PublicKey},
+	}, Options{Issuer: "https://issuer.example", Audience: "employees"})
+
+	tests := []struct {
+		name   string
+		header map[string]interface{}
+		signer interface{}
+	}{
+		{"HS256", map[string]interface{}{"alg": HS256}, testSecret},
+		{"RS256", map[string]interface{}{"alg": RS256, "kid": "rsa"}, rsaKey},
+		{"ES256", map[string]interface{}{"alg": ES256, "kid": "ec"}, ecKey},
+		{"ES256 without kid", map[string]interface{}{"alg": ES256}, ecKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := v.Verify(sign(t, tt.header, validClaims(), tt.signer))
+
+			require.NoError(t, err)
+			assert.Equal(t, "alice", claims.Subject)
+			assert.Equal(t, Audience{"employees", "other"}, claims.Audience)
+			assert.Equal(t, "alice", claims.Raw["sub"])
+		})
+	}
+}
+
+func TestVerifyRejects(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	v := newTestVerifier([]*Key{
+		{Algorithm: HS256, key: testSecret},
+		{ID: "rsa", key: &rsaKey.PublicKey},
+	}, Options{Issuer: "https://issuer.example", Audience: "employees", Leeway: 30 * time.Second})
+	hs := map[string]interface{}{"alg": HS256}
+
+	with := func(name string, value interface{}) map[string]interface{} {
+		claims := validClaims()
+		if value == nil {
+			delete(claims, name)
+		} else {
+			claims[name] = value
+		}
+		return claims
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		err   error
+	}{
+		{"malformed", "not.a-token", ErrMalformed},
+		{"none algorithm", sign(t, map[string]interface{}{"alg": "none"}, validClaims(), testSecret), ErrAlgorithm},
+		{"HS256 signed with the RSA public key", sign(t, hs, validClaims(), x509.MarshalPKCS1PublicKey(&rsaKey.PublicKey)), ErrSignature},
+		{"RS256 with an unknown kid", sign(t, map[string]interface{}{"alg": RS256, "kid": "other"}, validClaims(), rsaKey), ErrUnknownKey},
+		{"wrong secret", sign(t, hs, validClaims(), []byte(strings.Repeat("x", 32))), ErrSignature},
+		{"expired", sign(t, hs, with("exp", testNow.Add(-time.Minute).Unix()), testSecret), ErrExpired},
+		{"not valid yet", sign(t, hs, with("nbf", testNow.Add(time.Minute).Unix()), testSecret), ErrNotValidYet},
+		{"wrong issuer", sign(t, hs, with("iss", "https://other.example"), testSecret), ErrIssuer},
+		{"wrong audience", sign(t, hs, with("aud", "other"), testSecret), ErrAudience},
+		{"missing exp", sign(t, hs, with("exp", nil), testSecret), ErrMissingClaims},
+		{"missing sub", sign(t, hs, with("sub", nil), testSecret), ErrMissingClaims},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := v.Verify(tt.token)
+
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestVerifyLeeway(t *testing.T) {
+	v := newTestVerifier([]*Key{{key: testSecret}}, Options{Leeway: 30 * time.Second})
+	claims := validClaims()
+	claims["exp"] = testNow.Add(-10 * time.Second).Unix()
+	claims["nbf"] = testNow.Add(10 * time.Second).Unix()
+
+	_, err := v.Verify(sign(t, map[string]interface{}{"alg": HS256}, claims, testSecret))
+
+	assert.NoError(t, err)
+}
+
+func TestLoadKeys(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	require.NoError(t, err)
+	pemFile := writeFile(t, "signer.pem", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	secretFile := writeFile(t, "secret", append(testSecret, '\n'))
+
+	encode := func(i *big.Int) string { return base64.RawURLEncoding.EncodeToString(i.Bytes()) }
+	jwks, err := json.Marshal(map[string]interface{}{"keys": []map[string]string{
+		{"kty": "EC", "kid": "ec-1", "use": "sig", "crv": "P-256", "x": encode(ecKey.X), "y": encode(ecKey.Y)},
+		{"kty": "RSA", "kid": "enc-1", "use": "enc", "n": encode(rsaKey.N), "e": "AQAB"},
+		{"kty": "OKP", "kid": "ed-1", "crv": "Ed25519", "x": "AAAA"},
+	}})
+	require.NoError(t, err)
+	jwksFile := writeFile(t, "jwks.json", jwks)
+
+	keys, err := LoadKeys(secretFile, []string{pemFile}, jwksFile)
+	require.NoError(t, err)
+	require.Len(t, keys, 3)
+	assert.Equal(t, testSecret, keys[0].key)
+	assert.Equal(t, "signer", keys[1].ID)
+	assert.Equal(t, "ec-1", keys[2].ID)
+
+	v := newTestVerifier(keys, Options{})
+	for _, token := range []string{
+		sign(t, map[string]interface{}{"alg": HS256}, validClaims(), testSecret),
+		sign(t, map[string]interface{}{"alg": RS256, "kid": "signer"}, validClaims(), rsaKey),
+		sign(t, map[string]interface{}{"alg": ES256, "kid": "ec-1"}, validClaims(), ecKey),
+	} {
+		_, err := v.Verify(token)
+		assert.NoError(t, err)
+	}
+}
+
+func TestLoadKeysRejects(t *testing.T) {
+	_, err := LoadKeys("", nil, "")
+	assert.Error(t, err)
+
+	_, err = LoadHMACSecret(writeFile(t, "short", []byte("secret\n")))
+	assert.ErrorContains(t, err, "at least 32 bytes")
+
+	_, err = LoadJWKS(writeFile(t, "jwks.json", []byte(`{"keys":[{"kty":"EC","crv":"P-256","x":"AQ","y":"AQ"}]}`)))
+	assert.ErrorContains(t, err, "not on the P-256 curve")
+}
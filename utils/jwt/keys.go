@@ -0,0 +1,235 @@
+package jwt
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// minHMACSecret is the shortest HS256 secret accepted: RFC 7518 asks for
+// at least the size of the hash output.
+const minHMACSecret = 32
+
+// Key verifies the signatures of tokens. A key with an ID only verifies
+// tokens naming it in their kid header, or naming no key at all.
+type Key struct {
+	ID string
+	// Algorithm restricts the key to one algorithm; empty allows every
+	// algorithm matching the type of the key.
+	Algorithm string
+
+	// key is a []byte HMAC secret, *rsa.PublicKey or *ecdsa.PublicKey.
+	key interface{}
+}
+
+// LoadKeys loads the keys of every configured source. Empty paths are
+// skipped.
+func LoadKeys(hmacSecretFile string, publicKeyFiles []string, jwksFile string) ([]*Key, error) {
+	var keys []*Key
+	if hmacSecretFile != "" {
+		key, err := LoadHMACSecret(hmacSecretFile)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	for _, path := range publicKeyFiles {
+		loaded, err := LoadPublicKeys(path)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, loaded...)
+	}
+	if jwksFile != "" {
+		loaded, err := LoadJWKS(jwksFile)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, loaded...)
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no token verification keys configured")
+	}
+	return keys, nil
+}
+
+// LoadHMACSecret reads an HS256 secret. Trailing line breaks are not part
+// of the secret.
+func LoadHMACSecret(path string) (*Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read HMAC secret")
+	}
+	secret := bytes.TrimRight(data, "\r\n")
+	if len(secret) < minHMACSecret {
+		return nil, errors.Errorf("HMAC secret %s must be at least %d bytes long", path, minHMACSecret)
+	}
+	return &Key{Algorithm: HS256, key: secret}, nil
+}
+
+// LoadPublicKeys reads the RSA and ECDSA public keys of a PEM file, given
+// as PUBLIC KEY, RSA PUBLIC KEY or CERTIFICATE blocks. The keys are
+// identified by the file name without its extension.
+func LoadPublicKeys(path string) ([]*Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read public key")
+	}
+	id := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+
+	var keys []*Key
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		var public interface{}
+		switch block.Type {
+		case "PUBLIC KEY":
+			public, err = x509.ParsePKIXPublicKey(block.Bytes)
+		case "RSA PUBLIC KEY":
+			public, err = x509.ParsePKCS1PublicKey(block.Bytes)
+		case "CERTIFICATE":
+			var cert *x509.Certificate
+			if cert, err = x509.ParseCertificate(block.Bytes); err == nil {
+				public = cert.PublicKey
+			}
+		default:
+			return nil, errors.Errorf("unsupported PEM block %q in %s", block.Type, path)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse public key %s", path)
+		}
+
+		switch public.(type) {
+		case *rsa.PublicKey, *ecdsa.PublicKey:
+			keys = append(keys, &Key{ID: id, key: public})
+		default:
+			return nil, errors.Errorf("unsupported public key type %T in %s", public, path)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.Errorf("no public key found in %s", path)
+	}
+	return keys, nil
+}
+
+// jwk is a JSON Web Key (RFC 7517) of type RSA, EC or oct.
+type jwk struct {
+	Type      string `json:"kty"`
+	ID        string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+	N         string `json:"n"`
+	E         string `json:"e"`
+	Curve     string `json:"crv"`
+	X         string `json:"x"`
+	Y         string `json:"y"`
+	K         string `json:"k"`
+}
+
+// LoadJWKS reads the signature keys of a JWKS document. Encryption keys
+// and keys of other types are skipped.
+func LoadJWKS(path string) ([]*Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read JWKS")
+	}
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse JWKS %s", path)
+	}
+
+	var keys []*Key
+	for i, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.key()
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid key %d in JWKS %s", i, path)
+		}
+		if key != nil {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.Errorf("no signature key found in JWKS %s", path)
+	}
+	return keys, nil
+}
+
+// key converts k, returning nil for key types that are not supported.
+func (k jwk) key() (*Key, error) {
+	key := &Key{ID: k.ID, Algorithm: k.Algorithm}
+	switch k.Type {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, errors.New("RSA exponent is too large")
+		}
+		key.key = &rsa.PublicKey{N: n, E: int(e.Int64())}
+	case "EC":
+		if k.Curve != "P-256" {
+			return nil, nil
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		public := &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+		if !public.Curve.IsOnCurve(x, y) {
+			return nil, errors.New("EC point is not on the P-256 curve")
+		}
+		key.key = public
+	case "oct":
+		secret, err := base64.RawURLEncoding.DecodeString(k.K)
+		if err != nil {
+			return nil, err
+		}
+		if len(secret) < minHMACSecret {
+			return nil, errors.Errorf("oct key must be at least %d bytes long", minHMACSecret)
+		}
+		key.key = secret
+	default:
+		return nil, nil
+	}
+	return key, nil
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, errors.New("missing key parameter")
+	}
+	return new(big.Int).SetBytes(data), nil
+}
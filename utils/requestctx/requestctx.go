@@ -0,0 +1,32 @@
+package requestctx
+
+import (
+	"context"
+	"employee-management/domain/dto"
+)
+
+type requestIDKey struct{}
+
+type principalKey struct{}
+
+// WithRequestID returns a copy of ctx carrying the request ID.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestID returns the request ID carried by ctx, or an empty string.
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// WithPrincipal returns a copy of ctx carrying the caller identity.
+func WithPrincipal(ctx context.Context, principal *dto.Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// Principal returns the caller identity carried by ctx, if any.
+func Principal(ctx context.Context) (*dto.Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*dto.Principal)
+	return principal, ok && principal != nil
+}
@@ -0,0 +1,183 @@
+package validation
+
+import (
+	"employee-management/domain/apperror"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// Normalizer is implemented by requests that clean up their own values,
+// e.g. trimming whitespace, before they are validated.
+type Normalizer interface {
+	Normalize()
+}
+
+var validate = newValidate()
+
+func newValidate() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	// reuse the tags gin already understands
+	v.SetTagName("binding")
+	v.RegisterTagNameFunc(fieldName)
+	_ = v.RegisterValidation("nocontrol", noControl)
+	return v
+}
+
+// fieldName names struct fields after their JSON, form or uri tag so that
+// reported paths match what the client sent.
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form", "uri"} {
+		name := strings.SplitN(field.Tag.Get(tag), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// noControl rejects strings containing control characters such as newlines.
+func noControl(fl validator.FieldLevel) bool {
+	return strings.IndexFunc(fl.Field().String(), unicode.IsControl) < 0
+}
+
+// Struct normalizes and validates v, reporting every failing field in a
+// single apperror.KindValidation error.
+func Struct(v interface{}) error {
+	if n, ok := v.(Normalizer); ok {
+		n.Normalize()
+	}
+
+	err := validate.Struct(v)
+	if err == nil {
+		return nil
+	}
+
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return err
+	}
+
+	fields := make([]apperror.FieldError, 0, len(validationErrs))
+	for _, fe := range validationErrs {
+		fields = append(fields, apperror.FieldError{
+			Pointer: pointer(fe.Namespace()),
+			Code:    fieldCode(fe),
+			Message: fieldMessage(fe),
+		})
+	}
+
+	return apperror.ValidationFields("validation_failed", "request validation failed", fields)
+}
+
+// pointer turns a validator namespace such as "Request.items[0].name" into
+// the JSON pointer "/items/0/name".
+func pointer(namespace string) string {
+	// drop the name of the top level struct
+	if i := strings.Index(namespace, "."); i >= 0 {
+		namespace = namespace[i+1:]
+	} else {
+		namespace = ""
+	}
+
+	var b strings.Builder
+	for _, part := range strings.Split(namespace, ".") {
+		for _, segment := range strings.Split(strings.ReplaceAll(part, "]", ""), "[") {
+			segment = strings.ReplaceAll(segment, "~", "~0")
+			segment = strings.ReplaceAll(segment, "/", "~1")
+			b.WriteString("/" + segment)
+		}
+	}
+	return b.String()
+}
+
+func fieldCode(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required", "required_if", "required_unless":
+		return "field_required"
+	case "max":
+		return "field_too_long"
+	case "min":
+		return "field_too_short"
+	case "gt", "gte", "lt", "lte":
+		return "field_out_of_range"
+	default:
+		return "field_invalid"
+	}
+}
+
+func fieldMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required", "required_if", "required_unless":
+		return fmt.Sprintf("%s is required", fe.Field())
+	case "excluded_if", "excluded_unless":
+		return fmt.Sprintf("%s is not allowed", fe.Field())
+	case "max":
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("%s must be at most %s characters long", fe.Field(), fe.Param())
+		}
+		return fmt.Sprintf("%s must be at most %s", fe.Field(), fe.Param())
+	case "min":
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("%s must be at least %s characters long", fe.Field(), fe.Param())
+		}
+		return fmt.Sprintf("%s must be at least %s", fe.Field(), fe.Param())
+	case "gt":
+		return fmt.Sprintf("%s must be greater than %s", fe.Field(), fe.Param())
+	case "gte":
+		return fmt.Sprintf("%s must be greater than or equal to %s", fe.Field(), fe.Param())
+	case "lt":
+		return fmt.Sprintf("%s must be less than %s", fe.Field(), fe.Param())
+	case "lte":
+		return fmt.Sprintf("%s must be less than or equal to %s", fe.Field(), fe.Param())
+	case "oneof":
+		return fmt.Sprintf("%s must be one of %s", fe.Field(), fe.Param())
+	case "nocontrol":
+		return fmt.Sprintf("%s must not contain control characters", fe.Field())
+	case "iso4217":
+		return fmt.Sprintf("%s must be an ISO 4217 currency code", fe.Field())
+	case "datetime":
+		return fmt.Sprintf("%s must be formatted like %s", fe.Field(), fe.Param())
+	default:
+		return fmt.Sprintf("%s is invalid", fe.Field())
+	}
+}
+
+type ginValidator struct{}
+
+// Gin returns a binding.StructValidator that makes gin's Bind methods use
+// the same rules and error format as Struct.
+func Gin() binding.StructValidator {
+	return ginValidator{}
+}
+
+func (ginValidator) ValidateStruct(obj interface{}) error {
+	if obj == nil {
+		return nil
+	}
+
+	value := reflect.ValueOf(obj)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+
+	return Struct(obj)
+}
+
+func (ginValidator) Engine() interface{} {
+	return validate
+}
@@ -0,0 +1,69 @@
+package validation
+
+import (
+	"employee-management/domain/apperror"
+	"employee-management/domain/dto"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStructReportsEveryField(t *testing.T) {
+	req := &dto.EmployeeCreateRequest{
+		Name:     "   ",
+		Position: strings.Repeat("x", 256),
+		Salary:   -1,
+	}
+
+	err := Struct(req)
+
+	appErr, ok := apperror.As(err)
+	assert.True(t, ok)
+	assert.Equal(t, apperror.KindValidation, appErr.Kind)
+	assert.Equal(t, []apperror.FieldError{
+		{Pointer: "/name", Code: "field_required", Message: "name is required"},
+		{Pointer: "/position", Code: "field_too_long", Message: "position must be at most 255 characters long"},
+		{Pointer: "/salary", Code: "field_out_of_range", Message: "salary must be greater than 0"},
+	}, appErr.Fields)
+}
+
+func TestStructNormalizes(t *testing.T) {
+	req := &dto.EmployeeCreateRequest{
+		Name:     "  John Doe ",
+		Position: "\tDeveloper\n",
+		Salary:   60000,
+	}
+
+	err := Struct(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "John Doe", req.Name)
+	assert.Equal(t, "Developer", req.Position)
+}
+
+func TestStructRejectsControlCharacters(t *testing.T) {
+	err := Struct(&dto.EmployeeCreateRequest{Name: "John\nDoe", Position: "Developer", Salary: 1})
+
+	appErr, _ := apperror.As(err)
+	assert.Len(t, appErr.Fields, 1)
+	assert.Equal(t, "/name", appErr.Fields[0].Pointer)
+}
+
+func TestPointer(t *testing.T) {
+	assert.Equal(t, "/name", pointer("EmployeeCreateRequest.name"))
+	assert.Equal(t, "/items/0/name", pointer("Request.items[0].name"))
+	assert.Equal(t, "/a~1b", pointer("Request.a/b"))
+}
+
+func TestStructPointsIntoBatchOperations(t *testing.T) {
+	err := Struct(&dto.BatchRequest{Operations: []*dto.BatchOperation{
+		{Op: "create", Body: []byte(`{}`)},
+		{Op: "delete"},
+	}})
+
+	appErr, _ := apperror.As(err)
+	assert.Len(t, appErr.Fields, 1)
+	assert.Equal(t, "/operations/1/id", appErr.Fields[0].Pointer)
+	assert.Equal(t, "/operations/2/body/salary", pointer("BatchRequest.operations[2].body.salary"))
+}